@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"gopkg.in/yaml.v3"
+)
+
+// commands are run instead of serving the plugin when the binary is invoked
+// with their name as first argument. They read the Span API configured with
+// the same SPAN_ACCESS_TOKEN and SPAN_API_ENDPOINT variables as the provider.
+var commands = map[string]func(ctx context.Context, args []string) error{
+	"export":   export,
+	"snapshot": snapshot,
+}
+
+func newCatalogClient() (api.SpanAPIClient, error) {
+	token := os.Getenv("SPAN_ACCESS_TOKEN")
+	if token == "" {
+		return nil, errors.New("SPAN_ACCESS_TOKEN must be set to read the Span catalog")
+	}
+
+	opts := []api.ClientOption{api.WithToken(token)}
+	if endpoint := os.Getenv("SPAN_API_ENDPOINT"); endpoint != "" {
+		opts = append(opts, api.WithEndpoint(endpoint))
+	}
+
+	return api.NewSpanAPIClient(opts...)
+}
+
+// export dumps every person, team with its members and team manifest, sorted
+// so that exports of an unchanged catalog are identical. The JSON format is
+// the snapshot format served with snapshot_file.
+func export(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "json", "output format, json or yaml")
+	out := flags.String("out", "-", "path of the file to write, - for stdout")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "json" && *format != "yaml" {
+		return fmt.Errorf("unsupported format %q, expected json or yaml", *format)
+	}
+
+	client, err := newCatalogClient()
+	if err != nil {
+		return err
+	}
+
+	s, err := api.ExportSnapshot(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.EncodeJSON()
+	if err != nil {
+		return err
+	}
+
+	if *format == "yaml" {
+		if data, err = jsonToYAML(data); err != nil {
+			return err
+		}
+	}
+
+	if *out == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	return os.WriteFile(*out, data, 0o644)
+}
+
+// snapshot is an alias of export -format json, the format of the files which
+// the provider serves data sources from when snapshot_file is set.
+func snapshot(ctx context.Context, args []string) error {
+	return export(ctx, append([]string{"-format", "json"}, args...))
+}
+
+// jsonToYAML converts JSON to block style YAML. Going through yaml.Node keeps
+// the order of keys and the exact representation of numbers.
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	blockStyle(&node)
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// blockStyle drops the flow style which nodes parsed out of JSON carry,
+// along with the quoting of keys and strings which YAML doesn't need. Strings
+// which YAML 1.1 parsers read as booleans stay quoted.
+func blockStyle(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" || !yaml11Bools[node.Value] {
+		node.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// yaml11Bools are the plain scalars which YAML 1.1 resolves to booleans. The
+// encoder only quotes the YAML 1.2 ones on its own.
+var yaml11Bools = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true,
+	"n": true, "N": true, "no": true, "No": true, "NO": true,
+	"on": true, "On": true, "ON": true,
+	"off": true, "Off": true, "OFF": true,
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestJSONToYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			name: "block style",
+			json: `{"team": {"name": "Platform", "tags": ["a", "b"]}}`,
+			want: "team:\n  name: Platform\n  tags:\n    - a\n    - b\n",
+		},
+		{
+			name: "yaml 1.1 booleans",
+			json: `{"y": "y", "yes": "Yes", "no": "NO", "on": "on", "off": "Off", "bool": true}`,
+			want: "\"y\": \"y\"\n\"yes\": \"Yes\"\n\"no\": \"NO\"\n\"on\": \"on\"\n\"off\": \"Off\"\nbool: true\n",
+		},
+		{
+			name: "strings resolving to other types",
+			json: `{"true": "true", "null": "null", "number": "10", "float": "1.5"}`,
+			want: "\"true\": \"true\"\n\"null\": \"null\"\nnumber: \"10\"\nfloat: \"1.5\"\n",
+		},
+		{
+			name: "big numbers",
+			json: `{"id": 12345678901234567890123, "ratio": 0.10000000000000000555, "exp": 1e400}`,
+			want: "id: 12345678901234567890123\nratio: 0.10000000000000000555\nexp: 1e400\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonToYAML([]byte(tt.json))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
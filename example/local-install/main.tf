@@ -8,6 +8,41 @@ terraform {
 
 provider "span" {
   access_token = "<your PAT>"
+
+  # Optional client side rate limit shared by every data source & resource.
+  # Rate-limit headers returned by Span are always honored.
+  #
+  # requests_per_second = 10
+  # burst               = 20
+
+  # Optional retry policy for transient API failures (429, 5xx, network errors).
+  # Only idempotent requests are retried. Retry-After headers are honored.
+  #
+  # retry {
+  #   max_attempts = 4
+  #   min_backoff  = "500ms"
+  #   max_backoff  = "30s"
+  # }
+
+  # Optional in-memory cache of catalog reads, e.g. for_each over many teams
+  # by name lists the teams once. Writes through the provider invalidate it.
+  #
+  # cache {
+  #   enabled = true
+  #   ttl     = "5m"
+  # }
+
+  # Optional JSON Schema team manifest vendors are validated against at plan
+  # time, either as a file path or inline.
+  #
+  # manifest_schema = "${path.module}/manifest.schema.json"
+
+  # Optional catalog snapshot to serve data sources from without a token,
+  # e.g. for plans of pull requests from forks. Write one with:
+  #
+  #   SPAN_ACCESS_TOKEN=... terraform-provider-span snapshot -out span-snapshot.json
+  #
+  # snapshot_file = "${path.module}/span-snapshot.json"
 }
 
 #======================
@@ -16,6 +51,12 @@ provider "span" {
 # data "span_person" "john_smith" {
 #   email = "john@smith.com"
 # }
+#
+# # People can also be looked up by name or GitHub login, exactly one of
+# # email, name and github_login must be set.
+# data "span_person" "by_github" {
+#   github_login = "jsmith"
+# }
 
 # ## Example resource:
 # data "span_person" "john_smith" {
@@ -39,7 +80,10 @@ provider "span" {
 # span_people loads all people with additional optional filtering.
 #
 # data "span_people" "all" {
-#   team_ids = ["<id-1>", "<id-2>"]
+#   team_ids      = ["<id-1>", "<id-2>"] # optional, members of any of these teams
+#   emails        = ["john@smith.com"]   # optional, case insensitive
+#   name_contains = "smith"              # optional, case insensitive
+#   limit         = 50                   # optional
 # }
 #
 # ## Example resource:
@@ -72,20 +116,55 @@ provider "span" {
 #======================
 
 #======================
-# span_team provides information on an individual team by id or name
+# span_people_by_email resolves many emails at once, emails without a match
+# are listed in missing instead of failing the plan
+#
+# data "span_people_by_email" "owners" {
+#   emails          = ["john@smith.com", "jane@doe.com", "gone@example.com"]
+#   fail_on_missing = false # optional
+# }
+#
+# output "owner_github_logins" {
+#   value = { for email, p in data.span_people_by_email.owners.found : email => p.github_login }
+# }
+#
+# ## Example resource:
+# data "span_people_by_email" "owners" {
+#    found = {
+#        "john@smith.com" = { email = "john@smith.com", name = "John Smith", ... }
+#        "jane@doe.com"   = { email = "jane@doe.com", name = "Jane Doe", ... }
+#    }
+#    missing = ["gone@example.com"]
+# }
+#======================
+
+#======================
+# span_team provides information on an individual team by exactly one of id, name or slug
 #
 # data "span_team" "platform" {
 #   name = "Platform"
 #   # or
 #   id = "<team id>"
+#   # or
+#   slug = "platform"
+#
+#   # children and ancestors are only set with include_hierarchy, as they take
+#   # a listing of every team on each read.
+#   include_hierarchy = true
 # }
 #
 # ## Example resource:
 #
 # data "span_team" "platform" {
-#   id      = "5bbed53f-0e3c-488b-878e-2c4cfb131e5d"
-#   name    = "Team 1"
-#   slug    = "team-1"
+#   include_hierarchy = true
+#   id            = "5bbed53f-0e3c-488b-878e-2c4cfb131e5d"
+#   name          = "Team 1"
+#   slug          = "team-1"
+#   parent_id     = "0f6d1b52-3c1e-4a59-9d0e-7a1c2b9e4f11"
+#   created_at    = "2024-01-15T09:30:00Z"
+#   member_count  = 2
+#   lead_emails   = ["john@smith.com"]
+#   member_emails = ["jane@doe.com", "john@smith.com"]
 #    members = [
 #        {
 #            email     = "john@smith.com"
@@ -98,6 +177,16 @@ provider "span" {
 #            team_lead = false
 #        },
 #    ]
+#    children  = []
+#    ancestors = [
+#        {
+#            id         = "0f6d1b52-3c1e-4a59-9d0e-7a1c2b9e4f11"
+#            name       = "Engineering"
+#            slug       = "engineering"
+#            parent_id  = null
+#            created_at = "2023-11-02T10:00:00Z"
+#        },
+#    ]
 #}
 #
 #======================
@@ -112,18 +201,32 @@ provider "span" {
 # data "span_teams" "all" {
 #     teams = [
 #         {
-#             id   = "ccbed53f-0e3c-488b-878e-2c4cfb131e5d"
-#             name = "Team 1"
-#             slug = "team-1"
+#             id         = "ccbed53f-0e3c-488b-878e-2c4cfb131e5d"
+#             name       = "Team 1"
+#             slug       = "team-1"
+#             parent_id  = null
+#             created_at = "2024-01-15T09:30:00Z"
 #         },
 #         {
-#             id   = "049f1f94-f638-4284-b435-b2e998980b81"
-#             name = "Team 2"
-#             slug = "team-2"
+#             id         = "049f1f94-f638-4284-b435-b2e998980b81"
+#             name       = "Team 2"
+#             slug       = "team-2"
+#             parent_id  = "ccbed53f-0e3c-488b-878e-2c4cfb131e5d"
+#             created_at = "2024-03-02T14:00:00Z"
 #         },
 #     ]
 # }
 
+#======================
+# span_team_tree loads the hierarchy of teams under a root team, depth first.
+# Each team carries its depth and the ids of its ancestors within the tree,
+# e.g. ancestor_ids[1] is the director level team under an org root.
+#
+# data "span_team_tree" "engineering" {
+#   root_id   = "0f6d1b52-3c1e-4a59-9d0e-7a1c2b9e4f11"
+#   max_depth = 2 # optional
+# }
+
 # span_team_manifest loads the manifest for a specific team by team id
 #
 # data "span_team_manifest" "core_team_manifest" {
@@ -147,13 +250,66 @@ provider "span" {
 # Resources:
 # ===============
 
+# span_team resource allows to create and update teams. The slug is derived
+# from the name unless set, changing it replaces the team.
+# Import with either the team id or its slug.
+
+# resource "span_team" "core" {
+#   name      = "Core Team" # required
+#   slug      = "core-team"
+#   parent_id = span_team.engineering.id
+#}
+
+# span_team_members authoritatively manages who is on a team, members missing
+# from the configuration are removed. span_team_member manages a single
+# membership instead. Don't mix both for the same team.
+
+# resource "span_team_members" "core" {
+#   team_id = span_team.core.id # required
+#   members = [
+#     { email = "core-lead@span.app", team_lead = true },
+#     { email = "john@smith.com" },
+#   ]
+#}
+
+# resource "span_team_member" "jane" {
+#   team_id   = span_team.core.id # required
+#   email     = "jane@smith.com" # required
+#   team_lead = false
+#}
+
 # span_team_manifest resource allows to create and update
 # enumerated properties for a team's manifest
 
 # resource "span_team_manifest" "core_team_manifest" {
 #   team_id = "6d427a01-9c0a-4ec5-bdd0-a0c364c42baf" # required
 #   reference = "@span/core-team" # required
-#   vendors_input = jsonencode({"pagerduty": {"schedule": "PI7DH85"}})
+#   vendors = { pagerduty = { schedule = "PI7DH85" } }
+#   # or, as a JSON encoded string
+#   # vendors_input = jsonencode({"pagerduty": {"schedule": "PI7DH85"}})
+#}
+
+# Well known vendors can also be configured with typed settings, which are
+# validated at plan time. Other vendors go under custom.
+
+# resource "span_team_manifest" "core_team_manifest" {
+#   team_id = "6d427a01-9c0a-4ec5-bdd0-a0c364c42baf" # required
+#   reference = "@span/core-team" # required
+#   typed_vendors = {
+#     pagerduty = { schedule = "PI7DH85", escalation_policy = "PX1E2Q3" }
+#     slack     = { channel = "#core-team" }
+#     custom    = { statuspage = { page = "kctbh9vrtdwd" } }
+#   }
 #}
 
 # The output is equivalent to the data source schema.
+
+# span_team_manifest_vendor manages the settings of a single vendor within a
+# team manifest, leaving other vendors untouched. The manifest must exist.
+# Import with `<team_id>/<vendor>`.
+
+# resource "span_team_manifest_vendor" "core_pagerduty" {
+#   team_id  = "6d427a01-9c0a-4ec5-bdd0-a0c364c42baf" # required
+#   vendor   = "pagerduty" # required
+#   settings = { schedule = "PI7DH85" } # required
+#}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const DefaultCacheTTL = 5 * time.Minute
+
+// cachingClient serves repeated catalog reads from memory. Concurrent reads
+// of the same key share a single request. Any write drops every entry, as
+// e.g. a team membership change alters both the team and its people.
+type cachingClient struct {
+	SpanAPIClient
+
+	ttl time.Duration
+	now func() time.Time
+
+	mu sync.Mutex
+	// generation is bumped on every invalidation so that reads which were
+	// in flight during a write don't store what they fetched.
+	generation uint64
+	entries    map[string]cacheEntry
+	inflight   map[string]*cacheCall
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+type cacheCall struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func newCachingClient(next SpanAPIClient, ttl time.Duration) *cachingClient {
+	return &cachingClient{
+		SpanAPIClient: next,
+		ttl:           ttl,
+		now:           time.Now,
+		entries:       map[string]cacheEntry{},
+		inflight:      map[string]*cacheCall{},
+	}
+}
+
+// IsCaching reports whether client serves reads from the cache, in which case
+// e.g. listing every team once is cheaper than filtering repeatedly.
+func IsCaching(client SpanAPIClient) bool {
+	_, ok := client.(*cachingClient)
+	return ok
+}
+
+// cached returns the value stored for key, loading it with fetch on a miss.
+// Values are cloned on the way out as callers are free to modify them.
+//
+// The load is shared by every caller waiting for key, so it runs detached
+// from the cancellation of the caller which started it: each caller stops
+// waiting once its own ctx is done, the load carries on for the others. It
+// is still bounded by the timeout of the HTTP client.
+func cached[T any](ctx context.Context, c *cachingClient, key string, fetch func(context.Context) (T, error), clone func(T) T) (T, error) {
+	c.mu.Lock()
+
+	if entry, ok := c.entries[key]; ok && c.now().Before(entry.expires) {
+		c.mu.Unlock()
+		tflog.Debug(ctx, "Span API cache hit", map[string]any{"key": key})
+		return clone(entry.value.(T)), nil
+	}
+
+	call, ok := c.inflight[key]
+	if !ok {
+		call = &cacheCall{done: make(chan struct{})}
+		c.inflight[key] = call
+		go c.load(context.WithoutCancel(ctx), key, call, c.generation, func(ctx context.Context) (any, error) {
+			return fetch(ctx)
+		})
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+
+	if call.err != nil {
+		var zero T
+		return zero, call.err
+	}
+	return clone(call.value.(T)), nil
+}
+
+// load runs fetch for call and stores the value unless the cache was
+// invalidated since generation.
+func (c *cachingClient) load(ctx context.Context, key string, call *cacheCall, generation uint64, fetch func(context.Context) (any, error)) {
+	value, err := fetch(ctx)
+
+	c.mu.Lock()
+	if c.inflight[key] == call {
+		delete(c.inflight, key)
+	}
+	if err == nil && generation == c.generation {
+		c.entries[key] = cacheEntry{value: value, expires: c.now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+
+	call.value, call.err = value, err
+	close(call.done)
+}
+
+// invalidate drops every entry along with the reads in flight, later reads
+// go to the API again.
+func (c *cachingClient) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = map[string]cacheEntry{}
+	c.inflight = map[string]*cacheCall{}
+}
+
+func (c *cachingClient) FindPeople(ctx context.Context, r FindPeopleRequest) ([]PersonWithTeam, error) {
+	return cached(ctx, c, fmt.Sprintf("FindPeople %#v", r), func(ctx context.Context) ([]PersonWithTeam, error) {
+		return c.SpanAPIClient.FindPeople(ctx, r)
+	}, clonePeople)
+}
+
+func (c *cachingClient) FindPeoplePage(ctx context.Context, r FindPeopleRequest) (*FindPeopleResponse, error) {
+	return cached(ctx, c, fmt.Sprintf("FindPeoplePage %#v", r), func(ctx context.Context) (*FindPeopleResponse, error) {
+		return c.SpanAPIClient.FindPeoplePage(ctx, r)
+	}, cloneFindPeopleResponse)
+}
+
+func (c *cachingClient) FindTeams(ctx context.Context, r FindTeamsRequest) ([]Team, error) {
+	return cached(ctx, c, fmt.Sprintf("FindTeams %#v", r), func(ctx context.Context) ([]Team, error) {
+		return c.SpanAPIClient.FindTeams(ctx, r)
+	}, slices.Clone[[]Team])
+}
+
+func (c *cachingClient) FindTeamByID(ctx context.Context, teamID string) (*TeamWithMembers, error) {
+	return cached(ctx, c, "FindTeamByID "+teamID, func(ctx context.Context) (*TeamWithMembers, error) {
+		return c.SpanAPIClient.FindTeamByID(ctx, teamID)
+	}, cloneTeamWithMembers)
+}
+
+func (c *cachingClient) FindTeamManifestByTeamID(ctx context.Context, teamID string) (*TeamManifest, error) {
+	return cached(ctx, c, "FindTeamManifestByTeamID "+teamID, func(ctx context.Context) (*TeamManifest, error) {
+		return c.SpanAPIClient.FindTeamManifestByTeamID(ctx, teamID)
+	}, cloneTeamManifest)
+}
+
+// Writes invalidate the cache whether they succeed or not, a failed write
+// such as a version conflict means the cached state is likely stale.
+
+func (c *cachingClient) CreateTeam(ctx context.Context, r CreateTeamRequest) (*Team, error) {
+	defer c.invalidate()
+	return c.SpanAPIClient.CreateTeam(ctx, r)
+}
+
+func (c *cachingClient) UpdateTeam(ctx context.Context, teamID string, r UpdateTeamRequest) (*Team, error) {
+	defer c.invalidate()
+	return c.SpanAPIClient.UpdateTeam(ctx, teamID, r)
+}
+
+func (c *cachingClient) DeleteTeam(ctx context.Context, teamID string) error {
+	defer c.invalidate()
+	return c.SpanAPIClient.DeleteTeam(ctx, teamID)
+}
+
+func (c *cachingClient) SetTeamMember(ctx context.Context, teamID string, email string, r SetTeamMemberRequest) (*TeamMember, error) {
+	defer c.invalidate()
+	return c.SpanAPIClient.SetTeamMember(ctx, teamID, email, r)
+}
+
+func (c *cachingClient) RemoveTeamMember(ctx context.Context, teamID string, email string) error {
+	defer c.invalidate()
+	return c.SpanAPIClient.RemoveTeamMember(ctx, teamID, email)
+}
+
+func (c *cachingClient) SetTeamManifest(ctx context.Context, teamID string, r SetTeamManifestRequest) (*TeamManifest, error) {
+	defer c.invalidate()
+	return c.SpanAPIClient.SetTeamManifest(ctx, teamID, r)
+}
+
+func (c *cachingClient) DeleteTeamManifest(ctx context.Context, teamID string, version string) error {
+	defer c.invalidate()
+	return c.SpanAPIClient.DeleteTeamManifest(ctx, teamID, version)
+}
+
+func clonePeople(in []PersonWithTeam) []PersonWithTeam {
+	out := slices.Clone(in)
+	for i := range out {
+		out[i].Teams = slices.Clone(out[i].Teams)
+	}
+	return out
+}
+
+func cloneFindPeopleResponse(in *FindPeopleResponse) *FindPeopleResponse {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Data = clonePeople(in.Data)
+	return &out
+}
+
+func cloneTeamWithMembers(in *TeamWithMembers) *TeamWithMembers {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	out.Members = slices.Clone(in.Members)
+	return &out
+}
+
+func cloneTeamManifest(in *TeamManifest) *TeamManifest {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	if in.Vendors != nil {
+		out.Vendors = cloneValue(in.Vendors).(map[string]any)
+	}
+	return &out
+}
+
+// cloneValue deep copies a decoded free-form JSON value.
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = cloneValue(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = cloneValue(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
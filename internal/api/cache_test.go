@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingClient serves FindTeamByID once release is closed, so that tests
+// control how long loads stay in flight.
+type blockingClient struct {
+	SpanAPIClient
+
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+	// ctxErr is the error of the request context once the load completed.
+	ctxErr error
+}
+
+func newBlockingClient() *blockingClient {
+	return &blockingClient{started: make(chan struct{}), release: make(chan struct{})}
+}
+
+func (b *blockingClient) FindTeamByID(ctx context.Context, teamID string) (*TeamWithMembers, error) {
+	if b.calls.Add(1) == 1 {
+		close(b.started)
+	}
+
+	<-b.release
+	b.ctxErr = ctx.Err()
+
+	return &TeamWithMembers{Team: Team{NamedEntity: NamedEntity{ID: teamID, Name: "Platform"}}}, nil
+}
+
+func (b *blockingClient) SetTeamManifest(context.Context, string, SetTeamManifestRequest) (*TeamManifest, error) {
+	return &TeamManifest{}, nil
+}
+
+func TestCacheSingleFlight(t *testing.T) {
+	next := newBlockingClient()
+	c := newCachingClient(next, time.Minute)
+
+	const readers = 10
+
+	var wg sync.WaitGroup
+	teams := make([]*TeamWithMembers, readers)
+	errs := make([]error, readers)
+	for i := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			teams[i], errs[i] = c.FindTeamByID(context.Background(), "t-platform")
+		}()
+	}
+
+	<-next.started
+	close(next.release)
+	wg.Wait()
+
+	for i := range readers {
+		if errs[i] != nil {
+			t.Fatalf("reader %d: %v", i, errs[i])
+		}
+		if teams[i].Name != "Platform" {
+			t.Errorf("reader %d: got team %q", i, teams[i].Name)
+		}
+	}
+
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("got %d loads, want 1", calls)
+	}
+
+	// Readers get their own copy.
+	teams[0].Name = "Changed"
+	if teams[1].Name != "Platform" {
+		t.Error("readers share the cached value")
+	}
+}
+
+func TestCacheInvalidateDuringLoad(t *testing.T) {
+	next := newBlockingClient()
+	c := newCachingClient(next, time.Minute)
+
+	done := make(chan error)
+	go func() {
+		_, err := c.FindTeamByID(context.Background(), "t-platform")
+		done <- err
+	}()
+
+	<-next.started
+	if _, err := c.SetTeamManifest(context.Background(), "t-platform", SetTeamManifestRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	close(next.release)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	// What was loaded before the write must not be cached.
+	if _, err := c.FindTeamByID(context.Background(), "t-platform"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := next.calls.Load(); calls != 2 {
+		t.Errorf("got %d loads, want 2", calls)
+	}
+
+	// The load after the write is.
+	if _, err := c.FindTeamByID(context.Background(), "t-platform"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := next.calls.Load(); calls != 2 {
+		t.Errorf("got %d loads, want 2", calls)
+	}
+}
+
+func TestCacheCanceledInitiator(t *testing.T) {
+	next := newBlockingClient()
+	c := newCachingClient(next, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	initiator := make(chan error)
+	go func() {
+		_, err := c.FindTeamByID(ctx, "t-platform")
+		initiator <- err
+	}()
+
+	<-next.started
+
+	waiter := make(chan error)
+	go func() {
+		_, err := c.FindTeamByID(context.Background(), "t-platform")
+		waiter <- err
+	}()
+
+	cancel()
+	if err := <-initiator; !errors.Is(err, context.Canceled) {
+		t.Errorf("initiator: got %v, want %v", err, context.Canceled)
+	}
+
+	close(next.release)
+	if err := <-waiter; err != nil {
+		t.Errorf("waiter: %v", err)
+	}
+
+	if next.ctxErr != nil {
+		t.Errorf("load was canceled along with its initiator: %v", next.ctxErr)
+	}
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("got %d loads, want 1", calls)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	next := newBlockingClient()
+	close(next.release)
+
+	now := time.Now()
+	c := newCachingClient(next, time.Minute)
+	c.now = func() time.Time { return now }
+
+	for _, elapsed := range []time.Duration{0, 30 * time.Second, 2 * time.Minute} {
+		now = now.Add(elapsed)
+		if _, err := c.FindTeamByID(context.Background(), "t-platform"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls := next.calls.Load(); calls != 2 {
+		t.Errorf("got %d loads, want 2", calls)
+	}
+}
@@ -1,6 +1,13 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/imroc/req/v3"
 )
 
@@ -9,23 +16,55 @@ const (
 )
 
 type SpanAPIClient interface {
-	FindPeople(r FindPeopleRequest) ([]PersonWithTeam, error)
-	FindTeams(r FindTeamsRequest) ([]Team, error)
-	FindTeamByID(teamID string) (*TeamWithMembers, error)
-	FindTeamManifestByTeamID(teamID string) (*TeamManifest, error)
-	SetTeamManifest(teamID string, r SetTeamManifestRequest) (*TeamManifest, error)
-	DeleteTeamManifest(teamID string) error
+	FindPeople(ctx context.Context, r FindPeopleRequest) ([]PersonWithTeam, error)
+	// FindPeoplePage returns the first page of people matching r along with
+	// its paging details, e.g. to tell how many requests listing them takes.
+	FindPeoplePage(ctx context.Context, r FindPeopleRequest) (*FindPeopleResponse, error)
+	FindTeams(ctx context.Context, r FindTeamsRequest) ([]Team, error)
+	FindTeamByID(ctx context.Context, teamID string) (*TeamWithMembers, error)
+	CreateTeam(ctx context.Context, r CreateTeamRequest) (*Team, error)
+	UpdateTeam(ctx context.Context, teamID string, r UpdateTeamRequest) (*Team, error)
+	DeleteTeam(ctx context.Context, teamID string) error
+	// SetTeamMember adds the person with the given email to a team, or
+	// updates their membership if they already are a member.
+	SetTeamMember(ctx context.Context, teamID string, email string, r SetTeamMemberRequest) (*TeamMember, error)
+	RemoveTeamMember(ctx context.Context, teamID string, email string) error
+	FindTeamManifestByTeamID(ctx context.Context, teamID string) (*TeamManifest, error)
+	SetTeamManifest(ctx context.Context, teamID string, r SetTeamManifestRequest) (*TeamManifest, error)
+	// DeleteTeamManifest removes the manifest of a team. A non empty version
+	// is sent as If-Match, see SetTeamManifestRequest.IfMatch. It is retried
+	// like reads, so a not found error may mean an earlier attempt deleted it.
+	DeleteTeamManifest(ctx context.Context, teamID string, version string) error
 }
 
 type client struct {
 	endpoint   string
 	token      string
+	retry      RetryPolicy
+	limiter    *rateLimiter
 	httpClient *req.Client
 }
 
-func (c *client) FindPeople(r FindPeopleRequest) ([]PersonWithTeam, error) {
+func (c *client) FindPeople(ctx context.Context, r FindPeopleRequest) ([]PersonWithTeam, error) {
+	return collectPages[PersonWithTeam](ctx, c, r.Limit, func() *req.Request {
+		return c.findPeopleRequest(r)
+	})
+}
+
+func (c *client) FindPeoplePage(ctx context.Context, r FindPeopleRequest) (*FindPeopleResponse, error) {
 	var resp FindPeopleResponse
 
+	request := c.findPeopleRequest(r).
+		SetQueryParam("pageSize", strconv.Itoa(pageSize(r.Limit)))
+
+	if err := c.do(ctx, request, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *client) findPeopleRequest(r FindPeopleRequest) *req.Request {
 	request := c.httpClient.Get("/catalog/people")
 
 	if len(r.TeamIDs) > 0 {
@@ -36,108 +75,198 @@ func (c *client) FindPeople(r FindPeopleRequest) ([]PersonWithTeam, error) {
 		request.AddQueryParam("email", r.Email)
 	}
 
-	err := request.
-		Do().
-		Into(&resp)
+	if r.Name != "" {
+		request.AddQueryParam("name", r.Name)
+	}
 
-	if err != nil {
-		return nil, NewUnknownError()
+	if r.GitHubLogin != "" {
+		request.AddQueryParam("githubLogin", r.GitHubLogin)
 	}
 
-	return resp.Data, nil
+	return request
 }
 
-func (c *client) FindTeams(r FindTeamsRequest) ([]Team, error) {
-	var resp FindTeamsResponse
+func (c *client) FindTeams(ctx context.Context, r FindTeamsRequest) ([]Team, error) {
+	return collectPages[Team](ctx, c, r.Limit, func() *req.Request {
+		request := c.httpClient.Get("/catalog/teams")
 
-	request := c.httpClient.Get("/catalog/teams")
+		if r.Name != "" {
+			request.AddQueryParam("name", r.Name)
+		}
 
-	if r.Name != "" {
-		request.AddQueryParam("name", r.Name)
+		if r.Slug != "" {
+			request.AddQueryParam("slug", r.Slug)
+		}
+
+		return request
+	})
+}
+
+func (c *client) FindTeamByID(ctx context.Context, teamID string) (*TeamWithMembers, error) {
+	var resp FindTeamResponse
+
+	request := c.httpClient.Get("/catalog/teams/{teamID}").
+		SetPathParam("teamID", teamID)
+
+	if err := c.do(ctx, request, &resp); err != nil {
+		return nil, err
 	}
 
-	err := request.
-		Do().
-		Into(&resp)
+	return &resp.Data, nil
+}
 
-	if err != nil {
-		return nil, NewUnknownError()
+func (c *client) CreateTeam(ctx context.Context, r CreateTeamRequest) (*Team, error) {
+	var resp TeamResponse
+
+	request := c.httpClient.Post("/catalog/teams").
+		SetBody(r)
+
+	if err := c.do(ctx, request, &resp); err != nil {
+		return nil, err
 	}
 
-	return resp.Data, nil
+	return &resp.Data, nil
 }
 
-func (c *client) FindTeamByID(teamID string) (*TeamWithMembers, error) {
-	var resp FindTeamResponse
+func (c *client) UpdateTeam(ctx context.Context, teamID string, r UpdateTeamRequest) (*Team, error) {
+	var resp TeamResponse
 
-	err := c.httpClient.Get("/catalog/teams/{teamID}").
+	request := c.httpClient.Patch("/catalog/teams/{teamID}").
 		SetPathParam("teamID", teamID).
-		Do().
-		Into(&resp)
+		SetBody(r)
 
-	if err != nil {
-		return nil, NewUnknownError()
+	if err := c.do(ctx, request, &resp); err != nil {
+		return nil, err
 	}
 
 	return &resp.Data, nil
 }
 
-func (c *client) FindTeamManifestByTeamID(teamID string) (*TeamManifest, error) {
-	var resp FindTeamManifestResponse
+func (c *client) DeleteTeam(ctx context.Context, teamID string) error {
+	request := c.httpClient.Delete("/catalog/teams/{teamID}").
+		SetPathParam("teamID", teamID)
 
-	err := c.httpClient.Get("/catalog/teams/{teamID}/manifest").
+	return c.do(ctx, request, nil)
+}
+
+func (c *client) SetTeamMember(ctx context.Context, teamID string, email string, r SetTeamMemberRequest) (*TeamMember, error) {
+	var resp TeamMemberResponse
+
+	request := c.httpClient.Put("/catalog/teams/{teamID}/members/{email}").
 		SetPathParam("teamID", teamID).
-		Do().
-		Into(&resp)
+		SetPathParam("email", email).
+		SetBody(r)
 
-	if err != nil {
-		return nil, NewUnknownError()
+	if err := c.do(ctx, request, &resp); err != nil {
+		return nil, err
 	}
 
-	var manifest *TeamManifest
-	for k, m := range resp.Data {
-		manifest = &m
-		manifest.TeamReference = k
-		manifest.TeamID = teamID
+	return &resp.Data, nil
+}
+
+func (c *client) RemoveTeamMember(ctx context.Context, teamID string, email string) error {
+	request := c.httpClient.Delete("/catalog/teams/{teamID}/members/{email}").
+		SetPathParam("teamID", teamID).
+		SetPathParam("email", email)
+
+	return c.do(ctx, request, nil)
+}
+
+func (c *client) FindTeamManifestByTeamID(ctx context.Context, teamID string) (*TeamManifest, error) {
+	var resp FindTeamManifestResponse
+
+	request := c.httpClient.Get("/catalog/teams/{teamID}/manifest").
+		SetPathParam("teamID", teamID)
+
+	raw, err := c.exchange(ctx, request, &resp)
+	if err != nil {
+		return nil, err
 	}
 
-	return manifest, nil
+	return resp.manifest(teamID, raw.GetHeader("ETag")), nil
 }
 
-func (c *client) SetTeamManifest(teamID string, r SetTeamManifestRequest) (*TeamManifest, error) {
+func (c *client) SetTeamManifest(ctx context.Context, teamID string, r SetTeamManifestRequest) (*TeamManifest, error) {
 	var resp FindTeamManifestResponse
 
-	err := c.httpClient.Post("/catalog/teams/{teamID}/manifest").
-		SetPathParam("teamID", teamID).SetBody(r).Do().Into(&resp)
+	request := c.httpClient.Post("/catalog/teams/{teamID}/manifest").
+		SetPathParam("teamID", teamID).
+		SetBody(r)
 
+	if r.IfMatch != "" {
+		request.SetHeader("If-Match", r.IfMatch)
+	}
+
+	if r.IfNoneMatch != "" {
+		request.SetHeader("If-None-Match", r.IfNoneMatch)
+	}
+
+	raw, err := c.exchange(ctx, request, &resp)
 	if err != nil {
-		return nil, NewUnknownError()
+		return nil, err
 	}
 
-	var manifest *TeamManifest
-	for k, m := range resp.Data {
-		manifest = &m
-		manifest.TeamReference = k
-		manifest.TeamID = teamID
+	return resp.manifest(teamID, raw.GetHeader("ETag")), nil
+}
+
+func (c *client) DeleteTeamManifest(ctx context.Context, teamID string, version string) error {
+	request := c.httpClient.Delete("/catalog/teams/{teamID}/manifest").
+		SetPathParam("teamID", teamID)
+
+	if version != "" {
+		request.SetHeader("If-Match", version)
 	}
 
-	return manifest, nil
+	// A retry after a delete which went through gets a not found error,
+	// which callers already treat as the manifest being gone.
+	return c.do(replayable(ctx), request, nil)
 }
 
-func (c *client) DeleteTeamManifest(teamID string) error {
-	err := c.httpClient.Delete("/catalog/teams/{teamID}/manifest").
-		SetPathParam("teamID", teamID).
-		Do().ErrorResult()
+// do executes the request bound to ctx and decodes a successful response into out.
+// Non successful responses are mapped to a structured *Error.
+func (c *client) do(ctx context.Context, request *req.Request, out any) error {
+	_, err := c.exchange(ctx, request, out)
+	return err
+}
 
-	if err != nil {
-		return NewUnknownError()
+// exchange is do for callers which also need the response, e.g. its headers.
+func (c *client) exchange(ctx context.Context, request *req.Request, out any) (*req.Response, error) {
+	resp := c.retry.apply(request.SetContext(ctx)).Do()
+
+	if resp.Err != nil {
+		return nil, NewTransportError(resp.Err)
 	}
-	return nil
+
+	if !resp.IsSuccessState() {
+		return nil, NewErrorFromResponse(resp)
+	}
+
+	if out == nil || len(resp.Bytes()) == 0 {
+		return resp, nil
+	}
+
+	if err := resp.Into(out); err != nil {
+		return nil, NewDecodeError(resp, err)
+	}
+
+	return resp, nil
+}
+
+// unmarshalJSON decodes numbers within free-form values (e.g. manifest
+// vendors) as json.Number, so large ids survive without float rounding.
+func unmarshalJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
 }
 
 type clientOptions struct {
-	endpoint string
-	token    string
+	endpoint          string
+	token             string
+	retry             RetryPolicy
+	requestsPerSecond float64
+	burst             int
+	cacheTTL          time.Duration
 }
 
 type ClientOption func(*clientOptions) *clientOptions
@@ -156,19 +285,99 @@ func WithToken(token string) ClientOption {
 	}
 }
 
+// WithRetryPolicy replaces the retry policy as a whole.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(o *clientOptions) *clientOptions {
+		o.retry = policy
+		return o
+	}
+}
+
+// WithRetryMaxAttempts sets the maximum number of attempts, including the
+// initial request. A value of 1 disables retries.
+func WithRetryMaxAttempts(attempts int) ClientOption {
+	return func(o *clientOptions) *clientOptions {
+		o.retry.MaxAttempts = attempts
+		return o
+	}
+}
+
+// WithRetryBackoff sets the bounds of the exponential backoff between retries.
+func WithRetryBackoff(minBackoff, maxBackoff time.Duration) ClientOption {
+	return func(o *clientOptions) *clientOptions {
+		o.retry.MinBackoff = minBackoff
+		o.retry.MaxBackoff = maxBackoff
+		return o
+	}
+}
+
+// WithRetryJitter toggles randomization of the backoff between retries.
+func WithRetryJitter(jitter bool) ClientOption {
+	return func(o *clientOptions) *clientOptions {
+		o.retry.Jitter = jitter
+		return o
+	}
+}
+
+// WithRateLimit bounds the rate of requests shared by every call made through
+// the client. A non positive requestsPerSecond disables the local limit, while
+// rate-limit headers sent by the server are always honored.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(o *clientOptions) *clientOptions {
+		o.requestsPerSecond = requestsPerSecond
+		o.burst = burst
+		return o
+	}
+}
+
+// WithCache keeps the results of catalog reads in memory for ttl, sharing a
+// single request between concurrent identical reads. Writes through the
+// client invalidate the cache. A non positive ttl disables caching.
+func WithCache(ttl time.Duration) ClientOption {
+	return func(o *clientOptions) *clientOptions {
+		o.cacheTTL = ttl
+		return o
+	}
+}
+
 // NewSpanAPIClient instantiates a new client able to connect to the SPAN api
 func NewSpanAPIClient(opt ...ClientOption) (SpanAPIClient, error) {
 	opts := &clientOptions{
-		endpoint: DefaultEndpoint,
+		endpoint:          DefaultEndpoint,
+		retry:             DefaultRetryPolicy(),
+		requestsPerSecond: DefaultRequestsPerSecond,
+		burst:             DefaultBurst,
 	}
 
 	for _, funcOpt := range opt {
 		opts = funcOpt(opts)
 	}
 
-	return &client{
-		endpoint:   opts.endpoint,
-		token:      opts.token,
-		httpClient: req.C().SetBaseURL(opts.endpoint).SetCommonBearerAuthToken(opts.token),
-	}, nil
+	if opts.retry.MaxAttempts < 1 {
+		return nil, fmt.Errorf("retry max attempts must be at least 1, got %d", opts.retry.MaxAttempts)
+	}
+
+	if opts.retry.MinBackoff < 0 || opts.retry.MaxBackoff < opts.retry.MinBackoff {
+		return nil, fmt.Errorf("invalid retry backoff bounds [%s, %s]", opts.retry.MinBackoff, opts.retry.MaxBackoff)
+	}
+
+	limiter := newRateLimiter(opts.requestsPerSecond, opts.burst)
+
+	var c SpanAPIClient = &client{
+		endpoint: opts.endpoint,
+		token:    opts.token,
+		retry:    opts.retry,
+		limiter:  limiter,
+		httpClient: req.C().
+			SetBaseURL(opts.endpoint).
+			SetCommonBearerAuthToken(opts.token).
+			SetJsonUnmarshal(unmarshalJSON).
+			WrapRoundTripFunc(limiter.roundTrip),
+	}
+
+	if opts.cacheTTL > 0 {
+		c = newCachingClient(c, opts.cacheTTL)
+	}
+
+	return c, nil
 }
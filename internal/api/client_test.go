@@ -0,0 +1,108 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+)
+
+// newTestClient starts a fake Span API with the Platform team and returns a
+// client for it that retries quickly and isn't rate limited.
+func newTestClient(t *testing.T, opts ...apitest.Option) (api.SpanAPIClient, *apitest.Server) {
+	t.Helper()
+
+	srv := apitest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	srv.AddTeam(api.TeamWithMembers{Team: api.Team{NamedEntity: api.NamedEntity{ID: "t-platform", Name: "Platform"}, Slug: "platform"}})
+	srv.SetManifest("t-platform", api.TeamManifest{TeamName: "Platform", TeamReference: "platform"})
+
+	c, err := api.NewSpanAPIClient(
+		api.WithEndpoint(srv.Endpoint()),
+		api.WithToken("test"),
+		api.WithRetryBackoff(time.Millisecond, 10*time.Millisecond),
+		api.WithRateLimit(0, 1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c, srv
+}
+
+func TestClientRetries(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		route string
+		call  func(api.SpanAPIClient) error
+		want  int
+	}{
+		{
+			name:  "find team",
+			route: apitest.RouteFindTeam,
+			call: func(c api.SpanAPIClient) error {
+				_, err := c.FindTeamByID(ctx, "t-platform")
+				return err
+			},
+			want: 2,
+		},
+		{
+			name:  "delete manifest",
+			route: apitest.RouteDeleteManifest,
+			call: func(c api.SpanAPIClient) error {
+				return c.DeleteTeamManifest(ctx, "t-platform", "")
+			},
+			want: 2,
+		},
+		{
+			name:  "create team",
+			route: apitest.RouteCreateTeam,
+			call: func(c api.SpanAPIClient) error {
+				_, err := c.CreateTeam(ctx, api.CreateTeamRequest{Name: "Security"})
+				return err
+			},
+			want: 1,
+		},
+		{
+			name:  "update team",
+			route: apitest.RouteUpdateTeam,
+			call: func(c api.SpanAPIClient) error {
+				_, err := c.UpdateTeam(ctx, "t-platform", api.UpdateTeamRequest{Name: "Platform"})
+				return err
+			},
+			want: 1,
+		},
+		{
+			name:  "set member",
+			route: apitest.RouteSetMember,
+			call: func(c api.SpanAPIClient) error {
+				_, err := c.SetTeamMember(ctx, "t-platform", "ada@example.com", api.SetTeamMemberRequest{})
+				return err
+			},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv := newTestClient(t)
+			srv.InjectFault(tt.route, apitest.ServerError())
+
+			err := tt.call(c)
+			if tt.want > 1 && err != nil {
+				t.Fatalf("not retried: %v", err)
+			}
+			if tt.want == 1 && err == nil {
+				t.Fatal("retried a write")
+			}
+			if got := srv.Calls(tt.route); got != tt.want {
+				t.Errorf("got %d calls, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,25 +1,178 @@
 package api
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/imroc/req/v3"
+)
 
 type ErrorCode string
 
 const (
-	ErrorCodeUnknownError ErrorCode = "unknown_error"
+	ErrorCodeUnknownError     ErrorCode = "unknown_error"
+	ErrorCodeTransportError   ErrorCode = "transport_error"
+	ErrorCodeDecodeError      ErrorCode = "decode_error"
+	ErrorCodeNotFound         ErrorCode = "not_found"
+	ErrorCodeUnauthorized     ErrorCode = "unauthorized"
+	ErrorCodeForbidden        ErrorCode = "forbidden"
+	ErrorCodeRateLimited      ErrorCode = "rate_limited"
+	ErrorCodeValidationFailed ErrorCode = "validation_failed"
+	ErrorCodeConflict         ErrorCode = "conflict"
+	ErrorCodeServerError      ErrorCode = "server_error"
+	ErrorCodeReadOnly         ErrorCode = "read_only"
 )
 
-// Error is a proxy for API errors
-// @TODO: Coerce to human readable
+// RequestIDHeader is the response header Span uses to correlate requests.
+const RequestIDHeader = "X-Request-Id"
+
+// Error is a proxy for API errors.
+// Code is derived from the HTTP status so callers can branch on it, while
+// SpanCode and Message carry whatever the Span API reported in the body.
 type Error struct {
-	Code    ErrorCode
-	Message string
-	// response *ResponseWithMeta
+	Code       ErrorCode
+	Message    string
+	StatusCode int
+	SpanCode   string
+	RequestID  string
+	cause      error
 }
 
 func (e Error) Error() string {
-	return fmt.Sprintf("%s [%s]", e.Message, e.Code)
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s [%s]", e.Message, e.Code)
+
+	details := []string{}
+	if e.StatusCode != 0 {
+		details = append(details, fmt.Sprintf("status %d", e.StatusCode))
+	}
+	if e.SpanCode != "" && e.SpanCode != string(e.Code) {
+		details = append(details, fmt.Sprintf("span code %s", e.SpanCode))
+	}
+	if e.RequestID != "" {
+		details = append(details, fmt.Sprintf("request id %s", e.RequestID))
+	}
+
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+
+	return b.String()
+}
+
+func (e Error) Unwrap() error {
+	return e.cause
 }
 
 func NewUnknownError() error {
 	return &Error{Code: ErrorCodeUnknownError, Message: "Unexpected API error occurred"}
 }
+
+// NewReadOnlyError is returned for writes through a client serving a snapshot.
+func NewReadOnlyError() error {
+	return &Error{Code: ErrorCodeReadOnly, Message: "The Span catalog is served from a snapshot and can't be modified"}
+}
+
+// NewTransportError wraps failures that happened before a response was received.
+func NewTransportError(err error) error {
+	return &Error{Code: ErrorCodeTransportError, Message: fmt.Sprintf("Span API request failed: %v", err), cause: err}
+}
+
+// NewDecodeError wraps failures to decode a successful response body.
+func NewDecodeError(resp *req.Response, err error) error {
+	return &Error{
+		Code:       ErrorCodeDecodeError,
+		Message:    fmt.Sprintf("Could not decode Span API response: %v", err),
+		StatusCode: resp.GetStatusCode(),
+		RequestID:  resp.GetHeader(RequestIDHeader),
+		cause:      err,
+	}
+}
+
+// errorBody covers both the enveloped ({"error": {...}}) and flat error
+// payloads returned by the Span API.
+type errorBody struct {
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
+
+// NewErrorFromResponse maps a non successful HTTP response to an *Error.
+func NewErrorFromResponse(resp *req.Response) error {
+	e := &Error{
+		Code:       errorCodeForStatus(resp.GetStatusCode()),
+		StatusCode: resp.GetStatusCode(),
+		RequestID:  resp.GetHeader(RequestIDHeader),
+	}
+
+	var body errorBody
+	if err := json.Unmarshal(resp.Bytes(), &body); err == nil {
+		e.SpanCode, e.Message = body.Code, body.Message
+		if body.Error != nil {
+			e.SpanCode, e.Message = body.Error.Code, body.Error.Message
+		}
+		if e.RequestID == "" {
+			e.RequestID = body.RequestID
+		}
+	}
+
+	if e.Message == "" {
+		e.Message = http.StatusText(e.StatusCode)
+	}
+
+	if e.Message == "" {
+		e.Message = "Unexpected API error occurred"
+	}
+
+	return e
+}
+
+func errorCodeForStatus(status int) ErrorCode {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case status == http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case status == http.StatusForbidden:
+		return ErrorCodeForbidden
+	case status == http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return ErrorCodeValidationFailed
+	case status == http.StatusConflict, status == http.StatusPreconditionFailed:
+		return ErrorCodeConflict
+	case status >= http.StatusInternalServerError:
+		return ErrorCodeServerError
+	default:
+		return ErrorCodeUnknownError
+	}
+}
+
+// ErrorCodeOf returns the code of an API error, or unknown_error for
+// anything that did not originate from this package.
+func ErrorCodeOf(err error) ErrorCode {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ErrorCodeUnknownError
+}
+
+// IsNotFound reports whether err is a not_found API error.
+func IsNotFound(err error) bool {
+	return err != nil && ErrorCodeOf(err) == ErrorCodeNotFound
+}
+
+// IsConflict reports whether err is a conflict API error, which includes
+// writes rejected because their If-Match version is outdated.
+func IsConflict(err error) bool {
+	return err != nil && ErrorCodeOf(err) == ErrorCodeConflict
+}
@@ -0,0 +1,108 @@
+package api
+
+import "sort"
+
+// TeamHierarchy indexes teams by id and parent to navigate nested teams,
+// e.g. org → group → squad.
+type TeamHierarchy struct {
+	teams    map[string]Team
+	children map[string][]Team
+}
+
+// TeamNode is a team within a subtree along with its position.
+type TeamNode struct {
+	Team
+	// Depth is the distance to the root of the subtree, 0 for the root.
+	Depth int
+	// AncestorIDs lists the ids from the root of the subtree down to the
+	// parent of the team, empty for the root.
+	AncestorIDs []string
+}
+
+// NewTeamHierarchy indexes teams. Children are ordered by name, then id.
+func NewTeamHierarchy(teams []Team) *TeamHierarchy {
+	h := &TeamHierarchy{
+		teams:    make(map[string]Team, len(teams)),
+		children: map[string][]Team{},
+	}
+
+	for _, t := range teams {
+		h.teams[t.ID] = t
+		if t.ParentID != "" {
+			h.children[t.ParentID] = append(h.children[t.ParentID], t)
+		}
+	}
+
+	for _, children := range h.children {
+		sort.Slice(children, func(i, j int) bool {
+			if children[i].Name != children[j].Name {
+				return children[i].Name < children[j].Name
+			}
+			return children[i].ID < children[j].ID
+		})
+	}
+
+	return h
+}
+
+// Team returns the team with the given id.
+func (h *TeamHierarchy) Team(id string) (Team, bool) {
+	t, ok := h.teams[id]
+	return t, ok
+}
+
+// Children returns the direct children of a team.
+func (h *TeamHierarchy) Children(id string) []Team {
+	return h.children[id]
+}
+
+// Ancestors returns the parent of a team, its parent and so on up to the
+// top level. It stops at parents which are unknown or form a cycle.
+func (h *TeamHierarchy) Ancestors(id string) []Team {
+	var ancestors []Team
+
+	seen := map[string]bool{id: true}
+	for t, ok := h.teams[id]; ok && t.ParentID != "" && !seen[t.ParentID]; {
+		seen[t.ParentID] = true
+		if t, ok = h.teams[t.ParentID]; ok {
+			ancestors = append(ancestors, t)
+		}
+	}
+
+	return ancestors
+}
+
+// Subtree walks the teams under rootID depth first, the root included.
+// A negative maxDepth walks the whole subtree.
+func (h *TeamHierarchy) Subtree(rootID string, maxDepth int) []TeamNode {
+	root, ok := h.teams[rootID]
+	if !ok {
+		return nil
+	}
+
+	var nodes []TeamNode
+	seen := map[string]bool{}
+
+	var walk func(t Team, depth int, ancestorIDs []string)
+	walk = func(t Team, depth int, ancestorIDs []string) {
+		if seen[t.ID] {
+			return
+		}
+		seen[t.ID] = true
+
+		nodes = append(nodes, TeamNode{Team: t, Depth: depth, AncestorIDs: ancestorIDs})
+
+		if maxDepth >= 0 && depth >= maxDepth {
+			return
+		}
+
+		path := append(append([]string{}, ancestorIDs...), t.ID)
+		for _, child := range h.children[t.ID] {
+			walk(child, depth+1, path)
+		}
+	}
+
+	walk(root, 0, []string{})
+
+	return nodes
+}
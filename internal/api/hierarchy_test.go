@@ -0,0 +1,162 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newTestHierarchy indexes the teams
+//
+//	org
+//	├── Alpha (g-a)
+//	│   └── squad
+//	└── Beta (g-b, g-c)
+//
+// along with the cycle c1 → c2 → c3 → c1 (child → parent) and an orphan
+// whose parent is unknown.
+func newTestHierarchy() *TeamHierarchy {
+	team := func(id, name, parentID string) Team {
+		return Team{NamedEntity: NamedEntity{ID: id, Name: name}, ParentID: parentID}
+	}
+
+	return NewTeamHierarchy([]Team{
+		team("squad", "Squad", "g-a"),
+		team("g-c", "Beta", "org"),
+		team("g-b", "Beta", "org"),
+		team("g-a", "Alpha", "org"),
+		team("org", "Org", ""),
+		team("c1", "Cycle 1", "c2"),
+		team("c2", "Cycle 2", "c3"),
+		team("c3", "Cycle 3", "c1"),
+		team("self", "Self", "self"),
+		team("orphan", "Orphan", "missing"),
+	})
+}
+
+func teamIDs(teams []Team) []string {
+	var ids []string
+	for _, t := range teams {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestTeamHierarchyChildren(t *testing.T) {
+	h := newTestHierarchy()
+
+	if got, want := teamIDs(h.Children("org")), []string{"g-a", "g-b", "g-c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got children %q, want %q", got, want)
+	}
+	if got := h.Children("squad"); got != nil {
+		t.Errorf("got children %q of a leaf", teamIDs(got))
+	}
+}
+
+func TestTeamHierarchyAncestors(t *testing.T) {
+	h := newTestHierarchy()
+
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		{id: "squad", want: []string{"g-a", "org"}},
+		{id: "g-b", want: []string{"org"}},
+		{id: "org"},
+		// Cycles stop before getting back to the team.
+		{id: "c1", want: []string{"c2", "c3"}},
+		{id: "c3", want: []string{"c1", "c2"}},
+		{id: "self"},
+		{id: "orphan"},
+		{id: "missing"},
+	}
+
+	for _, tt := range tests {
+		if got := teamIDs(h.Ancestors(tt.id)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got ancestors %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestTeamHierarchySubtree(t *testing.T) {
+	h := newTestHierarchy()
+
+	type node struct {
+		ID          string
+		Depth       int
+		AncestorIDs []string
+	}
+
+	tests := []struct {
+		name     string
+		rootID   string
+		maxDepth int
+		want     []node
+	}{
+		{
+			name:     "root only",
+			rootID:   "org",
+			maxDepth: 0,
+			want:     []node{{"org", 0, []string{}}},
+		},
+		{
+			name:     "direct children",
+			rootID:   "org",
+			maxDepth: 1,
+			want: []node{
+				{"org", 0, []string{}},
+				{"g-a", 1, []string{"org"}},
+				{"g-b", 1, []string{"org"}},
+				{"g-c", 1, []string{"org"}},
+			},
+		},
+		{
+			name:     "unbounded",
+			rootID:   "org",
+			maxDepth: -1,
+			want: []node{
+				{"org", 0, []string{}},
+				{"g-a", 1, []string{"org"}},
+				{"squad", 2, []string{"org", "g-a"}},
+				{"g-b", 1, []string{"org"}},
+				{"g-c", 1, []string{"org"}},
+			},
+		},
+		{
+			name:     "inner root",
+			rootID:   "g-a",
+			maxDepth: -1,
+			want: []node{
+				{"g-a", 0, []string{}},
+				{"squad", 1, []string{"g-a"}},
+			},
+		},
+		{
+			name:     "cycle",
+			rootID:   "c1",
+			maxDepth: -1,
+			want: []node{
+				{"c1", 0, []string{}},
+				{"c3", 1, []string{"c1"}},
+				{"c2", 2, []string{"c1", "c3"}},
+			},
+		},
+		{
+			name:     "unknown root",
+			rootID:   "missing",
+			maxDepth: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []node
+			for _, n := range h.Subtree(tt.rootID, tt.maxDepth) {
+				got = append(got, node{n.ID, n.Depth, n.AncestorIDs})
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
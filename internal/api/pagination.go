@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/imroc/req/v3"
+)
+
+const (
+	// DefaultPageSize is the number of entries requested per page.
+	DefaultPageSize = 100
+	// maxPages guards against servers that keep reporting more pages.
+	maxPages = 10_000
+)
+
+// collectPages walks every page of a list endpoint and returns the
+// concatenated data, stopping early once limit entries were collected.
+// newRequest must return a fresh request for every page.
+func collectPages[T any](ctx context.Context, c *client, limit int, newRequest func() *req.Request) ([]T, error) {
+	var (
+		out    []T
+		cursor string
+		page   = 1
+	)
+
+	size := pageSize(limit)
+
+	for range maxPages {
+		request := newRequest().SetQueryParam("pageSize", strconv.Itoa(size))
+
+		if cursor != "" {
+			request.SetQueryParam("cursor", cursor)
+		} else if page > 1 {
+			request.SetQueryParam("page", strconv.Itoa(page))
+		}
+
+		var resp PagedResponse[T]
+		if err := c.do(ctx, request, &resp); err != nil {
+			return nil, err
+		}
+
+		out = append(out, resp.Data...)
+
+		if limit > 0 && len(out) >= limit {
+			return out[:limit], nil
+		}
+
+		meta := resp.Meta
+		if len(resp.Data) == 0 || (meta.TotalCount > 0 && len(out) >= meta.TotalCount) {
+			return out, nil
+		}
+
+		switch {
+		case meta.NextCursor != "" && meta.NextCursor != cursor:
+			cursor = meta.NextCursor
+		case meta.NextCursor == "" && meta.HasMore:
+			page = max(meta.Page, page) + 1
+		default:
+			return out, nil
+		}
+	}
+
+	return nil, &Error{Code: ErrorCodeUnknownError, Message: fmt.Sprintf("Pagination did not complete after %d pages", maxPages)}
+}
+
+// pageSize is the number of entries to request per page when at most limit
+// entries are wanted, 0 meaning all of them.
+func pageSize(limit int) int {
+	if limit > 0 && limit < DefaultPageSize {
+		return limit
+	}
+	return DefaultPageSize
+}
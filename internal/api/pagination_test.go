@@ -0,0 +1,131 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+)
+
+func TestCollectPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []apitest.Option
+		limit     int
+		wantTeams int
+		wantCalls int
+	}{
+		{
+			name:      "cursor",
+			opts:      []apitest.Option{apitest.WithPageSize(3)},
+			wantTeams: 8,
+			wantCalls: 3,
+		},
+		{
+			name:      "cursor up to limit",
+			opts:      []apitest.Option{apitest.WithPageSize(3)},
+			limit:     5,
+			wantTeams: 5,
+			wantCalls: 2,
+		},
+		{
+			name:      "cursor with full last page",
+			opts:      []apitest.Option{apitest.WithPageSize(4)},
+			wantTeams: 8,
+			wantCalls: 2,
+		},
+		{
+			name:      "page numbers",
+			opts:      []apitest.Option{apitest.WithPageSize(3), apitest.WithPageNumbers()},
+			wantTeams: 8,
+			wantCalls: 3,
+		},
+		{
+			name:      "page numbers up to limit",
+			opts:      []apitest.Option{apitest.WithPageSize(3), apitest.WithPageNumbers()},
+			limit:     4,
+			wantTeams: 4,
+			wantCalls: 2,
+		},
+		{
+			name:      "page numbers with full last page",
+			opts:      []apitest.Option{apitest.WithPageSize(4), apitest.WithPageNumbers()},
+			wantTeams: 8,
+			wantCalls: 2,
+		},
+		{
+			name:      "single page",
+			wantTeams: 8,
+			wantCalls: 1,
+		},
+		{
+			name:      "limit smaller than a page",
+			limit:     2,
+			wantTeams: 2,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv := newTestClient(t, tt.opts...)
+			for i := range 7 {
+				id := fmt.Sprintf("t-%02d", i)
+				srv.AddTeam(api.TeamWithMembers{Team: api.Team{NamedEntity: api.NamedEntity{ID: id, Name: id}, Slug: id}})
+			}
+
+			teams, err := c.FindTeams(context.Background(), api.FindTeamsRequest{Limit: tt.limit})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(teams) != tt.wantTeams {
+				t.Fatalf("got %d teams, want %d", len(teams), tt.wantTeams)
+			}
+			for i, team := range teams {
+				want := fmt.Sprintf("t-%02d", i)
+				if i == 7 {
+					want = "t-platform"
+				}
+				if team.ID != want {
+					t.Errorf("team %d: got %q, want %q", i, team.ID, want)
+				}
+			}
+
+			if got := srv.Calls(apitest.RouteFindTeams); got != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCollectPagesEndless(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data": [{"id": "t-loop"}], "meta": {"hasMore": true}}`)
+	}))
+	defer srv.Close()
+
+	c, err := api.NewSpanAPIClient(api.WithEndpoint(srv.URL), api.WithToken("test"), api.WithRateLimit(0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.FindTeams(context.Background(), api.FindTeamsRequest{})
+
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != api.ErrorCodeUnknownError {
+		t.Fatalf("got %v, want an unknown error", err)
+	}
+	if got := calls.Load(); got != 10_000 {
+		t.Errorf("got %d calls, want 10000", got)
+	}
+}
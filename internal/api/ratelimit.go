@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/imroc/req/v3"
+)
+
+const (
+	DefaultRequestsPerSecond = 10
+	DefaultBurst             = 20
+)
+
+// rateLimiter is a token bucket shared by every request of a client.
+// On top of the local budget it pauses all requests whenever the server
+// reports that its own budget is exhausted.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+
+	now func() time.Time
+}
+
+// newRateLimiter creates a limiter allowing rps requests per second with
+// bursts of up to burst requests. A non positive rps disables the local
+// budget, while server rate-limit headers are still honored.
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		delay := l.reserve(l.now())
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token when one is available, otherwise it returns how
+// long to wait before trying again.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// observe inspects rate-limit response headers and pauses the limiter
+// until the server side budget is replenished.
+func (l *rateLimiter) observe(resp *req.Response) {
+	if resp == nil || resp.Response == nil {
+		return
+	}
+
+	now := l.now()
+	var until time.Time
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if d, ok := retryAfter(resp); ok {
+			until = now.Add(d)
+		}
+	}
+
+	if remaining, ok := headerInt(resp, "X-RateLimit-Remaining", "RateLimit-Remaining"); ok && remaining <= 0 {
+		if reset, ok := headerInt(resp, "X-RateLimit-Reset", "RateLimit-Reset"); ok {
+			until = later(until, resetTime(now, reset))
+		}
+	}
+
+	if until.IsZero() {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pausedUntil = later(l.pausedUntil, until)
+}
+
+// resetTime interprets a reset header either as a unix timestamp or as a
+// number of seconds from now, depending on its magnitude.
+func resetTime(now time.Time, reset int64) time.Time {
+	const epochThreshold = 1_000_000_000
+
+	if reset >= epochThreshold {
+		return time.Unix(reset, 0)
+	}
+
+	return now.Add(time.Duration(reset) * time.Second)
+}
+
+func headerInt(resp *req.Response, names ...string) (int64, bool) {
+	for _, name := range names {
+		if v := resp.GetHeader(name); v != "" {
+			i, err := strconv.ParseInt(v, 10, 64)
+			return i, err == nil
+		}
+	}
+
+	return 0, false
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// roundTrip wraps the client transport so each attempt, retries included,
+// goes through the limiter.
+func (l *rateLimiter) roundTrip(rt req.RoundTripper) req.RoundTripFunc {
+	return func(r *req.Request) (*req.Response, error) {
+		if err := l.wait(r.Context()); err != nil {
+			return &req.Response{Request: r, Err: err}, err
+		}
+
+		resp, err := rt.RoundTrip(r)
+		l.observe(resp)
+
+		return resp, err
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+)
+
+// newTestRateLimiter returns a limiter whose clock is read from *now.
+func newTestRateLimiter(rps float64, burst int, now *time.Time) *rateLimiter {
+	l := newRateLimiter(rps, burst)
+	l.last = *now
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	now := start
+	l := newTestRateLimiter(2, 2, &now)
+
+	steps := []struct {
+		elapsed time.Duration
+		want    time.Duration
+	}{
+		// The burst is available right away.
+		{elapsed: 0, want: 0},
+		{elapsed: 0, want: 0},
+		// Then a token is added every 500ms.
+		{elapsed: 0, want: 500 * time.Millisecond},
+		{elapsed: 250 * time.Millisecond, want: 250 * time.Millisecond},
+		{elapsed: 500 * time.Millisecond, want: 0},
+		// Idle time doesn't accumulate more than the burst.
+		{elapsed: 10 * time.Second, want: 0},
+		{elapsed: 10 * time.Second, want: 0},
+		{elapsed: 10 * time.Second, want: 500 * time.Millisecond},
+	}
+
+	for i, s := range steps {
+		now = start.Add(s.elapsed)
+		if got := l.reserve(l.now()); got != s.want {
+			t.Errorf("step %d at +%s: got %s, want %s", i, s.elapsed, got, s.want)
+		}
+	}
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	l := newTestRateLimiter(0, 1, &now)
+
+	for range 100 {
+		if got := l.reserve(l.now()); got != 0 {
+			t.Fatalf("got %s, want no wait", got)
+		}
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		want    time.Duration
+	}{
+		{
+			name:    "retry after",
+			status:  http.StatusTooManyRequests,
+			headers: map[string]string{"Retry-After": "2"},
+			want:    2 * time.Second,
+		},
+		{
+			name:    "retry after on success",
+			status:  http.StatusOK,
+			headers: map[string]string{"Retry-After": "2"},
+		},
+		{
+			name:    "exhausted with relative reset",
+			status:  http.StatusOK,
+			headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "5"},
+			want:    5 * time.Second,
+		},
+		{
+			name:    "exhausted with epoch reset",
+			status:  http.StatusOK,
+			headers: map[string]string{"RateLimit-Remaining": "0", "RateLimit-Reset": "1700000030"},
+			want:    30 * time.Second,
+		},
+		{
+			name:    "budget left",
+			status:  http.StatusOK,
+			headers: map[string]string{"X-RateLimit-Remaining": "3", "X-RateLimit-Reset": "5"},
+		},
+		{
+			name:    "longest pause wins",
+			status:  http.StatusTooManyRequests,
+			headers: map[string]string{"Retry-After": "2", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "7"},
+			want:    7 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			l := newTestRateLimiter(0, 1, &now)
+
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+			l.observe(&req.Response{Response: &http.Response{StatusCode: tt.status, Header: header}})
+
+			if got := l.reserve(l.now()); got != tt.want {
+				t.Errorf("got a pause of %s, want %s", got, tt.want)
+			}
+
+			now = start.Add(tt.want)
+			if got := l.reserve(l.now()); got != 0 {
+				t.Errorf("still paused for %s once the pause elapsed", got)
+			}
+		})
+	}
+}
+
+func TestRateLimiterObserveKeepsLongerPause(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	l := newTestRateLimiter(0, 1, &now)
+
+	for _, retryAfter := range []string{"10", "1"} {
+		header := http.Header{"Retry-After": {retryAfter}}
+		l.observe(&req.Response{Response: &http.Response{StatusCode: http.StatusTooManyRequests, Header: header}})
+	}
+
+	if got := l.reserve(l.now()); got != 10*time.Second {
+		t.Errorf("got a pause of %s, want 10s", got)
+	}
+}
+
+func TestResetTime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		reset int64
+		want  time.Time
+	}{
+		{reset: 0, want: now},
+		{reset: 60, want: now.Add(time.Minute)},
+		{reset: 999_999_999, want: now.Add(999_999_999 * time.Second)},
+		{reset: 1_000_000_000, want: time.Unix(1_000_000_000, 0)},
+		{reset: 1_700_000_090, want: now.Add(90 * time.Second)},
+	}
+
+	for _, tt := range tests {
+		if got := resetTime(now, tt.reset); !got.Equal(tt.want) {
+			t.Errorf("reset %d: got %s, want %s", tt.reset, got, tt.want)
+		}
+	}
+}
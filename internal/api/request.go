@@ -1,15 +1,63 @@
 package api
 
+import "encoding/json"
+
 type FindPeopleRequest struct {
-	Email   string
-	TeamIDs []string
+	Email       string
+	Name        string
+	GitHubLogin string
+	TeamIDs     []string
+	// Limit caps the number of returned people, 0 returns every page.
+	Limit int
 }
 
 type FindTeamsRequest struct {
 	Name string
+	Slug string
+	// Limit caps the number of returned teams, 0 returns every page.
+	Limit int
+}
+
+type CreateTeamRequest struct {
+	Name string `json:"name"`
+	// Slug is derived from the name by Span when empty.
+	Slug     string `json:"slug,omitempty"`
+	ParentID string `json:"parentId,omitempty"`
+}
+
+// UpdateTeamRequest replaces the mutable properties of a team. The slug of a
+// team can't be changed. An empty ParentID moves the team to the top level.
+type UpdateTeamRequest struct {
+	Name     string
+	ParentID string
+}
+
+// MarshalJSON sends an empty ParentID as null, which detaches the team.
+func (r UpdateTeamRequest) MarshalJSON() ([]byte, error) {
+	body := struct {
+		Name     string  `json:"name"`
+		ParentID *string `json:"parentId"`
+	}{Name: r.Name}
+
+	if r.ParentID != "" {
+		body.ParentID = &r.ParentID
+	}
+
+	return json.Marshal(body)
+}
+
+type SetTeamMemberRequest struct {
+	TeamLead bool `json:"teamLead"`
 }
 
 type SetTeamManifestRequest struct {
 	Reference string         `json:"externalReference"`
 	Vendors   map[string]any `json:"vendors"`
+	// IfMatch is the version the manifest is expected to be at, as returned
+	// by FindTeamManifestByTeamID. The write fails with a conflict error if
+	// the manifest changed since. Empty writes unconditionally.
+	IfMatch string `json:"-"`
+	// IfNoneMatch set to "*" makes the write fail with a conflict error if
+	// the team already has a manifest, so that it is never overwritten.
+	IfNoneMatch string `json:"-"`
 }
@@ -1,6 +1,11 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type NamedEntity struct {
 	ID   string `json:"id"`
@@ -8,8 +13,15 @@ type NamedEntity struct {
 }
 
 type Person struct {
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	ID           string `json:"id,omitempty"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	Title        string `json:"title,omitempty"`
+	ManagerEmail string `json:"managerEmail,omitempty"`
+	GitHubLogin  string `json:"githubLogin,omitempty"`
+	SlackHandle  string `json:"slackHandle,omitempty"`
+	// Active is nil when Span does not report the status of the person.
+	Active *bool `json:"active,omitempty"`
 }
 
 type PersonWithTeam struct {
@@ -25,6 +37,7 @@ type TeamMember struct {
 type Team struct {
 	NamedEntity
 	Slug      string    `json:"slug"`
+	ParentID  string    `json:"parentId,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 }
 
@@ -34,36 +47,84 @@ type TeamWithMembers struct {
 }
 
 type TeamManifest struct {
-	TeamID        string
+	TeamID        string         `json:"team_id,omitempty"`
 	TeamName      string         `json:"pretty_name"`
 	TeamReference string         `json:"external_reference"`
 	TechLead      string         `json:"tech_lead"`
 	Vendors       map[string]any `json:"vendors"`
+	// Version identifies the revision of the manifest, taken from the ETag
+	// header or the version field of the payload. It is passed back as
+	// If-Match to detect concurrent modifications.
+	Version string `json:"-"`
 }
 
+// Meta carries the paging details of list responses. Span either hands out
+// an opaque NextCursor or page numbers alongside HasMore.
 type Meta struct {
+	NextCursor string `json:"nextCursor"`
+	HasMore    bool   `json:"hasMore"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	TotalCount int    `json:"totalCount"`
 }
 
 type ResponseWithMeta struct {
 	Meta Meta `json:"meta"`
 }
 
-type FindPeopleResponse struct {
+// PagedResponse is a single page of a list endpoint.
+type PagedResponse[T any] struct {
 	ResponseWithMeta
-	Data []PersonWithTeam `json:"data"`
+	Data []T `json:"data"`
 }
 
-type FindTeamsResponse struct {
-	ResponseWithMeta
-	Data []Team `json:"data"`
-}
+type FindPeopleResponse = PagedResponse[PersonWithTeam]
+
+type FindTeamsResponse = PagedResponse[Team]
 
 type FindTeamResponse struct {
 	ResponseWithMeta
 	Data TeamWithMembers `json:"data"`
 }
 
+type TeamResponse struct {
+	ResponseWithMeta
+	Data Team `json:"data"`
+}
+
+type TeamMemberResponse struct {
+	ResponseWithMeta
+	Data TeamMember `json:"data"`
+}
+
 type FindTeamManifestResponse struct {
 	ResponseWithMeta
-	Data map[string]TeamManifest `json:"data"`
+	Data map[string]versionedManifest `json:"data"`
+}
+
+// versionedManifest is a TeamManifest along with the version field of the payload.
+type versionedManifest struct {
+	TeamManifest
+	Version json.RawMessage `json:"version"`
+}
+
+// manifest extracts the manifest of teamID out of the response. The etag
+// header takes precedence over the version field of the payload.
+func (r FindTeamManifestResponse) manifest(teamID string, etag string) *TeamManifest {
+	var manifest *TeamManifest
+	for k, m := range r.Data {
+		manifest = &m.TeamManifest
+		manifest.TeamReference = k
+		manifest.TeamID = teamID
+
+		if v := strings.Trim(string(m.Version), `"`); v != "" && v != "null" {
+			manifest.Version = strconv.Quote(v)
+		}
+	}
+
+	if manifest != nil && etag != "" {
+		manifest.Version = etag
+	}
+
+	return manifest
 }
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/imroc/req/v3"
+)
+
+const (
+	DefaultRetryMaxAttempts = 4
+	DefaultRetryMinBackoff  = 500 * time.Millisecond
+	DefaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy describes how failed requests are retried.
+// MaxAttempts counts the initial request, so a value of 1 disables retries.
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	// Jitter randomizes each backoff between half and the full interval,
+	// spreading out retries of concurrent requests.
+	Jitter bool
+	// RetryNonIdempotent enables retries of requests that are not safe to
+	// replay, such as POSTs. Disabled by default.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the policy used when no retry options are given.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		MinBackoff:  DefaultRetryMinBackoff,
+		MaxBackoff:  DefaultRetryMaxBackoff,
+		Jitter:      true,
+	}
+}
+
+// retryableStatuses are the transient response statuses worth a retry.
+var retryableStatuses = map[int]bool{
+	http.StatusRequestTimeout:      true,
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+type replayableKey struct{}
+
+// replayable marks requests sent with ctx as safe to retry even though their
+// method isn't. Only calls whose replay is harmless qualify, e.g. a delete
+// which at worst reports that the entity is already gone.
+func replayable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayableKey{}, true)
+}
+
+// isIdempotent reports whether r may be retried by default: reads, and the
+// requests marked as replayable. Other writes, PUTs and DELETEs included,
+// are only retried with RetryNonIdempotent.
+func isIdempotent(r *req.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	replay, _ := r.Context().Value(replayableKey{}).(bool)
+	return replay
+}
+
+// apply configures the retry behavior of a single request.
+func (p RetryPolicy) apply(r *req.Request) *req.Request {
+	if p.MaxAttempts <= 1 || (!isIdempotent(r) && !p.RetryNonIdempotent) {
+		return r
+	}
+
+	return r.
+		SetRetryCount(p.MaxAttempts - 1).
+		SetRetryInterval(p.wait).
+		SetRetryCondition(shouldRetry)
+}
+
+func shouldRetry(resp *req.Response, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	if resp == nil || resp.Response == nil {
+		return false
+	}
+
+	return retryableStatuses[resp.StatusCode]
+}
+
+// wait sleeps for the backoff interval itself so that cancelling the
+// request context aborts the backoff, and hands a zero interval back to req.
+func (p RetryPolicy) wait(resp *req.Response, attempt int) time.Duration {
+	d := p.interval(resp, attempt)
+
+	if resp == nil || resp.Request == nil {
+		return d
+	}
+
+	ctx := resp.Request.Context()
+	tflog.Debug(ctx, "retrying Span API request", map[string]any{
+		"attempt": attempt,
+		"backoff": d.String(),
+		"url":     resp.Request.RawURL,
+	})
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	return 0
+}
+
+// interval honors the Retry-After header when present and falls back to a
+// capped exponential backoff otherwise. attempt starts at 1 for the first retry.
+func (p RetryPolicy) interval(resp *req.Response, attempt int) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return min(d, p.MaxBackoff)
+	}
+
+	backoff := float64(p.MinBackoff) * math.Exp2(float64(attempt-1))
+	backoff = math.Min(backoff, float64(p.MaxBackoff))
+
+	if p.Jitter && backoff >= 2 {
+		half := int64(backoff / 2)
+		return time.Duration(half + rand.Int64N(half))
+	}
+
+	return time.Duration(backoff)
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(resp *req.Response) (time.Duration, bool) {
+	if resp == nil || resp.Response == nil {
+		return 0, false
+	}
+
+	value := resp.GetHeader("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
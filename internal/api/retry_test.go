@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+)
+
+func TestRetryInterval(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, w := range want {
+		if got := p.interval(nil, i+1); got != w {
+			t.Errorf("attempt %d: got %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestRetryIntervalJitter(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Jitter: true}
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		full := min(p.MinBackoff<<(attempt-1), p.MaxBackoff)
+		for range 100 {
+			if got := p.interval(nil, attempt); got < full/2 || got >= full {
+				t.Fatalf("attempt %d: got %s, want within [%s, %s)", attempt, got, full/2, full)
+			}
+		}
+	}
+}
+
+func TestRetryIntervalRetryAfter(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		retryAfter string
+		want       time.Duration
+	}{
+		{retryAfter: "3", want: 3 * time.Second},
+		{retryAfter: "0", want: 0},
+		// Capped at MaxBackoff.
+		{retryAfter: "120", want: 10 * time.Second},
+		{retryAfter: time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), want: 10 * time.Second},
+		{retryAfter: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), want: 0},
+		// Invalid values fall back to the backoff.
+		{retryAfter: "soon", want: 200 * time.Millisecond},
+		{retryAfter: "-1", want: 200 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		resp := &req.Response{Response: &http.Response{Header: http.Header{"Retry-After": {tt.retryAfter}}}}
+		if got := p.interval(resp, 2); got != tt.want {
+			t.Errorf("Retry-After %q: got %s, want %s", tt.retryAfter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SnapshotFormatVersion is the version of the snapshot file format written
+// by this package. Snapshots of other versions are rejected on read.
+const SnapshotFormatVersion = 1
+
+// Snapshot is an export of the Span catalog: every person, team with its
+// members and team manifest. Entries are sorted so that exports of the same
+// catalog are identical.
+type Snapshot struct {
+	FormatVersion int               `json:"format_version"`
+	People        []PersonWithTeam  `json:"people"`
+	Teams         []TeamWithMembers `json:"teams"`
+	Manifests     []TeamManifest    `json:"manifests"`
+}
+
+// ExportSnapshot reads the whole catalog through client.
+func ExportSnapshot(ctx context.Context, client SpanAPIClient) (*Snapshot, error) {
+	s := &Snapshot{FormatVersion: SnapshotFormatVersion}
+
+	people, err := client.FindPeople(ctx, FindPeopleRequest{})
+	if err != nil {
+		return nil, err
+	}
+	s.People = people
+
+	teams, err := client.FindTeams(ctx, FindTeamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range teams {
+		team, err := client.FindTeamByID(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		s.Teams = append(s.Teams, *team)
+
+		manifest, err := client.FindTeamManifestByTeamID(ctx, t.ID)
+		if err != nil && !IsNotFound(err) {
+			return nil, err
+		}
+		if manifest != nil {
+			s.Manifests = append(s.Manifests, *manifest)
+		}
+	}
+
+	s.sort()
+
+	return s, nil
+}
+
+func (s *Snapshot) sort() {
+	sort.Slice(s.People, func(i, j int) bool { return s.People[i].Email < s.People[j].Email })
+	for _, p := range s.People {
+		sort.Slice(p.Teams, func(i, j int) bool { return p.Teams[i].ID < p.Teams[j].ID })
+	}
+
+	sort.Slice(s.Teams, func(i, j int) bool { return s.Teams[i].ID < s.Teams[j].ID })
+	for _, t := range s.Teams {
+		sort.Slice(t.Members, func(i, j int) bool { return t.Members[i].Email < t.Members[j].Email })
+	}
+
+	sort.Slice(s.Manifests, func(i, j int) bool { return s.Manifests[i].TeamID < s.Manifests[j].TeamID })
+
+	// Versions identify revisions on the server, they mean nothing offline
+	// and would make every export differ.
+	for i := range s.Manifests {
+		s.Manifests[i].Version = ""
+	}
+}
+
+// ReadSnapshotFile loads a snapshot encoded by EncodeJSON, as written by the
+// export and snapshot commands.
+func ReadSnapshotFile(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Snapshot
+	if err := unmarshalJSON(data, &s); err != nil {
+		return nil, fmt.Errorf("could not decode snapshot %s: %w", path, err)
+	}
+
+	if s.FormatVersion != SnapshotFormatVersion {
+		return nil, fmt.Errorf("snapshot %s has format version %d, expected %d", path, s.FormatVersion, SnapshotFormatVersion)
+	}
+
+	return &s, nil
+}
+
+// EncodeJSON encodes the snapshot as indented JSON, the format read by
+// ReadSnapshotFile.
+func (s *Snapshot) EncodeJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// snapshotClient serves reads from a snapshot, writes are rejected.
+type snapshotClient struct {
+	snapshot *Snapshot
+}
+
+// NewSnapshotClient returns a read-only client backed by a snapshot. Lookups
+// apply the same filters as the Span API.
+func NewSnapshotClient(s *Snapshot) SpanAPIClient {
+	return &snapshotClient{snapshot: s}
+}
+
+func (c *snapshotClient) FindPeople(_ context.Context, r FindPeopleRequest) ([]PersonWithTeam, error) {
+	var people []PersonWithTeam
+	for _, p := range c.snapshot.People {
+		if r.Email != "" && !strings.EqualFold(p.Email, r.Email) {
+			continue
+		}
+		if r.Name != "" && !strings.EqualFold(p.Name, r.Name) {
+			continue
+		}
+		if r.GitHubLogin != "" && !strings.EqualFold(p.GitHubLogin, r.GitHubLogin) {
+			continue
+		}
+		if len(r.TeamIDs) > 0 && !slices.ContainsFunc(p.Teams, func(t NamedEntity) bool { return slices.Contains(r.TeamIDs, t.ID) }) {
+			continue
+		}
+		people = append(people, p)
+	}
+
+	if r.Limit > 0 && len(people) > r.Limit {
+		people = people[:r.Limit]
+	}
+
+	return clonePeople(people), nil
+}
+
+func (c *snapshotClient) FindPeoplePage(ctx context.Context, r FindPeopleRequest) (*FindPeopleResponse, error) {
+	people, err := c.FindPeople(ctx, FindPeopleRequest{TeamIDs: r.TeamIDs, Email: r.Email, Name: r.Name, GitHubLogin: r.GitHubLogin})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &FindPeopleResponse{Data: people}
+	resp.Meta = Meta{Page: 1, PageSize: pageSize(r.Limit), TotalCount: len(people)}
+
+	if len(people) > resp.Meta.PageSize {
+		resp.Data = people[:resp.Meta.PageSize]
+		resp.Meta.HasMore = true
+	}
+
+	return resp, nil
+}
+
+func (c *snapshotClient) FindTeams(_ context.Context, r FindTeamsRequest) ([]Team, error) {
+	var teams []Team
+	for _, t := range c.snapshot.Teams {
+		if r.Name != "" && t.Name != r.Name {
+			continue
+		}
+		if r.Slug != "" && t.Slug != r.Slug {
+			continue
+		}
+		teams = append(teams, t.Team)
+	}
+
+	if r.Limit > 0 && len(teams) > r.Limit {
+		teams = teams[:r.Limit]
+	}
+
+	return teams, nil
+}
+
+func (c *snapshotClient) FindTeamByID(_ context.Context, teamID string) (*TeamWithMembers, error) {
+	for i, t := range c.snapshot.Teams {
+		if t.ID == teamID {
+			return cloneTeamWithMembers(&c.snapshot.Teams[i]), nil
+		}
+	}
+
+	return nil, newSnapshotNotFoundError("Team")
+}
+
+func (c *snapshotClient) FindTeamManifestByTeamID(_ context.Context, teamID string) (*TeamManifest, error) {
+	for i, m := range c.snapshot.Manifests {
+		if m.TeamID == teamID {
+			return cloneTeamManifest(&c.snapshot.Manifests[i]), nil
+		}
+	}
+
+	return nil, newSnapshotNotFoundError("Team manifest")
+}
+
+func (c *snapshotClient) CreateTeam(context.Context, CreateTeamRequest) (*Team, error) {
+	return nil, NewReadOnlyError()
+}
+
+func (c *snapshotClient) UpdateTeam(context.Context, string, UpdateTeamRequest) (*Team, error) {
+	return nil, NewReadOnlyError()
+}
+
+func (c *snapshotClient) DeleteTeam(context.Context, string) error {
+	return NewReadOnlyError()
+}
+
+func (c *snapshotClient) SetTeamMember(context.Context, string, string, SetTeamMemberRequest) (*TeamMember, error) {
+	return nil, NewReadOnlyError()
+}
+
+func (c *snapshotClient) RemoveTeamMember(context.Context, string, string) error {
+	return NewReadOnlyError()
+}
+
+func (c *snapshotClient) SetTeamManifest(context.Context, string, SetTeamManifestRequest) (*TeamManifest, error) {
+	return nil, NewReadOnlyError()
+}
+
+func (c *snapshotClient) DeleteTeamManifest(context.Context, string, string) error {
+	return NewReadOnlyError()
+}
+
+func newSnapshotNotFoundError(entity string) error {
+	return &Error{Code: ErrorCodeNotFound, Message: entity + " not found in snapshot"}
+}
@@ -0,0 +1,347 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+)
+
+// newSnapshotTestClient returns a client for a fake Span API seeded out of
+// order, so that exports have to sort.
+func newSnapshotTestClient(t *testing.T) api.SpanAPIClient {
+	t.Helper()
+
+	c, srv := newTestClient(t, apitest.WithPageSize(2))
+
+	ada := api.Person{ID: "p-ada", Email: "ada@example.com", Name: "Ada Lovelace", GitHubLogin: "ada"}
+	grace := api.Person{ID: "p-grace", Email: "grace@example.com", Name: "Grace Hopper", GitHubLogin: "Grace"}
+	hedy := api.Person{ID: "p-hedy", Email: "hedy@example.com", Name: "Hedy Lamarr"}
+
+	platform := api.NamedEntity{ID: "t-platform", Name: "Platform"}
+	eng := api.NamedEntity{ID: "t-eng", Name: "Engineering"}
+
+	srv.AddTeam(api.TeamWithMembers{
+		Team:    api.Team{NamedEntity: platform, Slug: "platform", ParentID: "t-eng"},
+		Members: []api.TeamMember{{Person: grace}, {Person: ada, TeamLead: true}},
+	})
+	srv.AddTeam(api.TeamWithMembers{
+		Team:    api.Team{NamedEntity: eng, Slug: "engineering"},
+		Members: []api.TeamMember{{Person: hedy}, {Person: grace}},
+	})
+	srv.AddTeam(api.TeamWithMembers{
+		Team: api.Team{NamedEntity: api.NamedEntity{ID: "t-data", Name: "Data"}, Slug: "data", ParentID: "t-eng"},
+	})
+
+	srv.AddPerson(api.PersonWithTeam{Person: hedy, Teams: []api.NamedEntity{eng}})
+	srv.AddPerson(api.PersonWithTeam{Person: grace, Teams: []api.NamedEntity{platform, eng}})
+	srv.AddPerson(api.PersonWithTeam{Person: ada, Teams: []api.NamedEntity{platform}})
+
+	srv.SetManifest("t-eng", api.TeamManifest{TeamName: "Engineering", TeamReference: "engineering"})
+
+	return c
+}
+
+func TestExportSnapshot(t *testing.T) {
+	ctx := context.Background()
+	c := newSnapshotTestClient(t)
+
+	s, err := api.ExportSnapshot(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.FormatVersion != api.SnapshotFormatVersion {
+		t.Errorf("got format version %d, want %d", s.FormatVersion, api.SnapshotFormatVersion)
+	}
+
+	var people, personTeams, teams, members, manifests []string
+	for _, p := range s.People {
+		people = append(people, p.Email)
+	}
+	for _, team := range s.People[1].Teams {
+		personTeams = append(personTeams, team.ID)
+	}
+	for _, team := range s.Teams {
+		teams = append(teams, team.ID)
+	}
+	for _, m := range s.Teams[2].Members {
+		members = append(members, m.Email)
+	}
+	for _, m := range s.Manifests {
+		manifests = append(manifests, m.TeamID)
+		if m.Version != "" {
+			t.Errorf("manifest of %s keeps version %s", m.TeamID, m.Version)
+		}
+	}
+
+	checks := []struct {
+		name      string
+		got, want []string
+	}{
+		{"people", people, []string{"ada@example.com", "grace@example.com", "hedy@example.com"}},
+		{"teams of grace", personTeams, []string{"t-eng", "t-platform"}},
+		{"teams", teams, []string{"t-data", "t-eng", "t-platform"}},
+		{"members of platform", members, []string{"ada@example.com", "grace@example.com"}},
+		{"manifests", manifests, []string{"t-eng", "t-platform"}},
+	}
+	for _, check := range checks {
+		if !reflect.DeepEqual(check.got, check.want) {
+			t.Errorf("%s: got %q, want %q", check.name, check.got, check.want)
+		}
+	}
+
+	// Exports of an unchanged catalog are identical.
+	again, err := api.ExportSnapshot(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := s.EncodeJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := again.EncodeJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("exports differ:\n%s\n%s", first, second)
+	}
+}
+
+// writeSnapshot exports the catalog of c into a snapshot file.
+func writeSnapshot(t *testing.T, c api.SpanAPIClient) string {
+	t.Helper()
+
+	s, err := api.ExportSnapshot(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := s.EncodeJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "span-snapshot.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestReadSnapshotFile(t *testing.T) {
+	path := writeSnapshot(t, newSnapshotTestClient(t))
+
+	s, err := api.ReadSnapshotFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.People) != 3 || len(s.Teams) != 3 || len(s.Manifests) != 2 {
+		t.Errorf("got %d people, %d teams and %d manifests", len(s.People), len(s.Teams), len(s.Manifests))
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"future.json":  `{"format_version": 2, "people": [], "teams": [], "manifests": []}`,
+		"missing.json": `{"people": [], "teams": [], "manifests": []}`,
+		"invalid.json": `{"format_version": 1, "people": {}}`,
+	}
+	wantErrs := map[string]string{
+		"future.json":  "has format version 2, expected 1",
+		"missing.json": "has format version 0, expected 1",
+		"invalid.json": "could not decode snapshot",
+		"absent.json":  "no such file",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, want := range wantErrs {
+		_, err := api.ReadSnapshotFile(filepath.Join(dir, name))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: got error %v, want %q", name, err, want)
+		}
+	}
+}
+
+func TestSnapshotClientFilters(t *testing.T) {
+	ctx := context.Background()
+	live := newSnapshotTestClient(t)
+
+	s, err := api.ReadSnapshotFile(writeSnapshot(t, live))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := api.NewSnapshotClient(s)
+
+	peopleRequests := []api.FindPeopleRequest{
+		{},
+		{Email: "GRACE@example.com"},
+		{Name: "ada lovelace"},
+		{Name: "Ada"},
+		{GitHubLogin: "grace"},
+		{TeamIDs: []string{"t-eng"}},
+		{TeamIDs: []string{"t-eng", "t-platform"}},
+		{TeamIDs: []string{"t-data"}},
+		{TeamIDs: []string{"t-platform"}, GitHubLogin: "ada"},
+		{Limit: 2},
+	}
+
+	for _, r := range peopleRequests {
+		want, err := live.FindPeople(ctx, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := snapshot.FindPeople(ctx, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if g, w := personIDs(got), personIDs(want); !sameResults(g, w, r.Limit) {
+			t.Errorf("people %+v: got %q, live API returns %q", r, g, w)
+		}
+	}
+
+	teamRequests := []api.FindTeamsRequest{
+		{},
+		{Name: "Platform"},
+		{Name: "platform"},
+		{Slug: "engineering"},
+		{Slug: "Engineering"},
+		{Name: "Data", Slug: "platform"},
+		{Limit: 1},
+	}
+
+	for _, r := range teamRequests {
+		want, err := live.FindTeams(ctx, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := snapshot.FindTeams(ctx, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if g, w := teamIDs(got), teamIDs(want); !sameResults(g, w, r.Limit) {
+			t.Errorf("teams %+v: got %q, live API returns %q", r, g, w)
+		}
+	}
+}
+
+// sameResults compares the ids returned by a snapshot with those of the live
+// API. Snapshots are sorted while the API keeps its own order, so limited
+// results may only agree on their size.
+func sameResults(got, want []string, limit int) bool {
+	if limit > 0 {
+		return len(got) == len(want)
+	}
+	return reflect.DeepEqual(slices.Sorted(slices.Values(got)), slices.Sorted(slices.Values(want)))
+}
+
+func personIDs(people []api.PersonWithTeam) []string {
+	var ids []string
+	for _, p := range people {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func teamIDs(teams []api.Team) []string {
+	var ids []string
+	for _, t := range teams {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestSnapshotClientLookups(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := api.ReadSnapshotFile(writeSnapshot(t, newSnapshotTestClient(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := api.NewSnapshotClient(s)
+
+	team, err := c.FindTeamByID(ctx, "t-platform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.Name != "Platform" || team.ParentID != "t-eng" || len(team.Members) != 2 || !team.Members[0].TeamLead {
+		t.Errorf("got team %+v", team)
+	}
+
+	// Callers get their own copy.
+	team.Members[0].Email = "changed@example.com"
+	if again, _ := c.FindTeamByID(ctx, "t-platform"); again.Members[0].Email != "ada@example.com" {
+		t.Error("lookups share the snapshot")
+	}
+
+	manifest, err := c.FindTeamManifestByTeamID(ctx, "t-eng")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.TeamReference != "engineering" || manifest.TeamName != "Engineering" {
+		t.Errorf("got manifest %+v", manifest)
+	}
+
+	if _, err := c.FindTeamByID(ctx, "t-missing"); !api.IsNotFound(err) {
+		t.Errorf("missing team: got %v, want a not found error", err)
+	}
+	if _, err := c.FindTeamManifestByTeamID(ctx, "t-data"); !api.IsNotFound(err) {
+		t.Errorf("missing manifest: got %v, want a not found error", err)
+	}
+}
+
+func TestSnapshotClientWrites(t *testing.T) {
+	ctx := context.Background()
+	c := api.NewSnapshotClient(&api.Snapshot{FormatVersion: api.SnapshotFormatVersion})
+
+	writes := map[string]func() error{
+		"CreateTeam": func() error {
+			_, err := c.CreateTeam(ctx, api.CreateTeamRequest{Name: "Security"})
+			return err
+		},
+		"UpdateTeam": func() error {
+			_, err := c.UpdateTeam(ctx, "t-platform", api.UpdateTeamRequest{Name: "Platform"})
+			return err
+		},
+		"DeleteTeam": func() error {
+			return c.DeleteTeam(ctx, "t-platform")
+		},
+		"SetTeamMember": func() error {
+			_, err := c.SetTeamMember(ctx, "t-platform", "ada@example.com", api.SetTeamMemberRequest{})
+			return err
+		},
+		"RemoveTeamMember": func() error {
+			return c.RemoveTeamMember(ctx, "t-platform", "ada@example.com")
+		},
+		"SetTeamManifest": func() error {
+			_, err := c.SetTeamManifest(ctx, "t-platform", api.SetTeamManifestRequest{Reference: "platform"})
+			return err
+		},
+		"DeleteTeamManifest": func() error {
+			return c.DeleteTeamManifest(ctx, "t-platform", "")
+		},
+	}
+
+	for name, write := range writes {
+		var apiErr *api.Error
+		if err := write(); !errors.As(err, &apiErr) || apiErr.Code != api.ErrorCodeReadOnly {
+			t.Errorf("%s: got %v, want a read only error", name, err)
+		}
+	}
+}
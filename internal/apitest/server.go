@@ -0,0 +1,765 @@
+// Package apitest provides an in-memory fake of the Span catalog API for
+// tests. It serves the same endpoints as the real API from an httptest
+// server, can be seeded with fixtures and can inject faults per route.
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+)
+
+// Route names accepted by InjectFault, matching the endpoints of the fake.
+const (
+	RouteFindPeople     = "GET /catalog/people"
+	RouteFindTeams      = "GET /catalog/teams"
+	RouteFindTeam       = "GET /catalog/teams/{teamID}"
+	RouteCreateTeam     = "POST /catalog/teams"
+	RouteUpdateTeam     = "PATCH /catalog/teams/{teamID}"
+	RouteDeleteTeam     = "DELETE /catalog/teams/{teamID}"
+	RouteSetMember      = "PUT /catalog/teams/{teamID}/members/{email}"
+	RouteRemoveMember   = "DELETE /catalog/teams/{teamID}/members/{email}"
+	RouteFindManifest   = "GET /catalog/teams/{teamID}/manifest"
+	RouteSetManifest    = "POST /catalog/teams/{teamID}/manifest"
+	RouteDeleteManifest = "DELETE /catalog/teams/{teamID}/manifest"
+)
+
+// Server is a fake Span API. The zero value is not usable, use NewServer.
+type Server struct {
+	*httptest.Server
+
+	token       string
+	pageSize    int
+	pageNumbers bool
+
+	mu        sync.Mutex
+	people    []api.PersonWithTeam
+	teams     map[string]*api.TeamWithMembers
+	manifests map[string]api.TeamManifest
+	versions  map[string]int
+	faults    map[string][]*Fault
+	calls     map[string]int
+
+	requestSeq atomic.Int64
+	teamSeq    atomic.Int64
+}
+
+type Option func(*Server)
+
+// WithToken makes the server reject requests without the given bearer token.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithPageSize caps the page size of list endpoints, forcing pagination.
+func WithPageSize(size int) Option {
+	return func(s *Server) {
+		s.pageSize = size
+	}
+}
+
+// WithPageNumbers makes list endpoints paginate with page numbers and
+// HasMore instead of cursors, without reporting a total count.
+func WithPageNumbers() Option {
+	return func(s *Server) {
+		s.pageNumbers = true
+	}
+}
+
+// NewServer starts a fake Span API. Callers must Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		pageSize:  api.DefaultPageSize,
+		teams:     map[string]*api.TeamWithMembers{},
+		manifests: map[string]api.TeamManifest{},
+		versions:  map[string]int{},
+		faults:    map[string][]*Fault{},
+		calls:     map[string]int{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	s.handle(mux, RouteFindPeople, s.findPeople)
+	s.handle(mux, RouteFindTeams, s.findTeams)
+	s.handle(mux, RouteFindTeam, s.findTeam)
+	s.handle(mux, RouteCreateTeam, s.createTeam)
+	s.handle(mux, RouteUpdateTeam, s.updateTeam)
+	s.handle(mux, RouteDeleteTeam, s.deleteTeam)
+	s.handle(mux, RouteSetMember, s.setMember)
+	s.handle(mux, RouteRemoveMember, s.removeMember)
+	s.handle(mux, RouteFindManifest, s.findManifest)
+	s.handle(mux, RouteSetManifest, s.setManifest)
+	s.handle(mux, RouteDeleteManifest, s.deleteManifest)
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Endpoint returns the base URL to configure as api_endpoint.
+func (s *Server) Endpoint() string {
+	return s.URL
+}
+
+// AddPerson seeds a person. Team memberships are taken from p.Teams.
+func (s *Server) AddPerson(p api.PersonWithTeam) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.people = append(s.people, p)
+}
+
+// AddTeam seeds a team along with its members.
+func (s *Server) AddTeam(t api.TeamWithMembers) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.teams[t.ID] = &t
+}
+
+// SetManifest seeds the manifest of a team.
+func (s *Server) SetManifest(teamID string, m api.TeamManifest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m.TeamID = teamID
+	s.manifests[teamID] = m
+	s.versions[teamID]++
+}
+
+// DeleteManifest removes the manifest of a team, e.g. to simulate a removal
+// outside of Terraform.
+func (s *Server) DeleteManifest(teamID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.manifests, teamID)
+	s.versions[teamID]++
+}
+
+// Manifest returns the stored manifest of a team.
+func (s *Server) Manifest(teamID string) (api.TeamManifest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.manifests[teamID]
+	return m, ok
+}
+
+// Calls returns how many requests reached the given route, faults included.
+func (s *Server) Calls(route string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[route]
+}
+
+func (s *Server) handle(mux *http.ServeMux, route string, h http.HandlerFunc) {
+	mux.HandleFunc(route, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(api.RequestIDHeader, fmt.Sprintf("apitest-%d", s.requestSeq.Add(1)))
+
+		s.mu.Lock()
+		s.calls[route]++
+		fault := s.nextFault(route)
+		s.mu.Unlock()
+
+		if fault != nil && fault.serve(w, r) {
+			return
+		}
+
+		if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Invalid access token")
+			return
+		}
+
+		h(w, r)
+	})
+}
+
+func (s *Server) findPeople(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	teamIDs := query["teamIds"]
+	email := query.Get("email")
+	name := query.Get("name")
+	githubLogin := query.Get("githubLogin")
+
+	s.mu.Lock()
+	people := make([]api.PersonWithTeam, 0, len(s.people))
+	for _, p := range s.people {
+		if email != "" && !strings.EqualFold(p.Email, email) {
+			continue
+		}
+		if name != "" && !strings.EqualFold(p.Name, name) {
+			continue
+		}
+		if githubLogin != "" && !strings.EqualFold(p.GitHubLogin, githubLogin) {
+			continue
+		}
+		if len(teamIDs) > 0 && !inAnyTeam(p, teamIDs) {
+			continue
+		}
+		people = append(people, p)
+	}
+	s.mu.Unlock()
+
+	writePage(w, r, s.pageSize, s.pageNumbers, people)
+}
+
+func inAnyTeam(p api.PersonWithTeam, teamIDs []string) bool {
+	for _, t := range p.Teams {
+		for _, id := range teamIDs {
+			if t.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Server) findTeams(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	name := query.Get("name")
+	slug := query.Get("slug")
+
+	s.mu.Lock()
+	teams := make([]api.Team, 0, len(s.teams))
+	for _, t := range s.teams {
+		if name != "" && t.Name != name {
+			continue
+		}
+		if slug != "" && t.Slug != slug {
+			continue
+		}
+		teams = append(teams, t.Team)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+
+	writePage(w, r, s.pageSize, s.pageNumbers, teams)
+}
+
+func (s *Server) findTeam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	t, ok := s.teams[r.PathValue("teamID")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "team_not_found", "Team not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": t})
+}
+
+// Team returns the stored team.
+func (s *Server) Team(teamID string) (api.TeamWithMembers, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.teams[teamID]
+	if !ok {
+		return api.TeamWithMembers{}, false
+	}
+	return *t, true
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request) {
+	var body api.CreateTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+		return
+	}
+
+	if body.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "validation_failed", "name is required")
+		return
+	}
+
+	if body.Slug == "" {
+		body.Slug = slugify(body.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status, code, msg := s.checkTeam("", body.Slug, body.ParentID); status != 0 {
+		writeError(w, status, code, msg)
+		return
+	}
+
+	t := &api.TeamWithMembers{
+		Team: api.Team{
+			NamedEntity: api.NamedEntity{ID: fmt.Sprintf("team-%d", s.teamSeq.Add(1)), Name: body.Name},
+			Slug:        body.Slug,
+			ParentID:    body.ParentID,
+			CreatedAt:   time.Now().UTC().Truncate(time.Second),
+		},
+	}
+	s.teams[t.ID] = t
+
+	writeJSON(w, http.StatusCreated, map[string]any{"data": t.Team})
+}
+
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request) {
+	teamID := r.PathValue("teamID")
+
+	var body struct {
+		Name     string  `json:"name"`
+		ParentID *string `json:"parentId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.teams[teamID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "team_not_found", "Team not found")
+		return
+	}
+
+	parentID := ""
+	if body.ParentID != nil {
+		parentID = *body.ParentID
+	}
+
+	if status, code, msg := s.checkTeam(teamID, t.Slug, parentID); status != 0 {
+		writeError(w, status, code, msg)
+		return
+	}
+
+	if body.Name != "" {
+		t.Name = body.Name
+	}
+	t.ParentID = parentID
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": t.Team})
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	teamID := r.PathValue("teamID")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.teams[teamID]; !ok {
+		writeError(w, http.StatusNotFound, "team_not_found", "Team not found")
+		return
+	}
+
+	for _, t := range s.teams {
+		if t.ParentID == teamID {
+			writeError(w, http.StatusConflict, "conflict", "Team still has child teams")
+			return
+		}
+	}
+
+	delete(s.teams, teamID)
+	delete(s.manifests, teamID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setMember(w http.ResponseWriter, r *http.Request) {
+	teamID, email := r.PathValue("teamID"), r.PathValue("email")
+
+	var body api.SetTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.teams[teamID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "team_not_found", "Team not found")
+		return
+	}
+
+	var person *api.PersonWithTeam
+	for i := range s.people {
+		if strings.EqualFold(s.people[i].Email, email) {
+			person = &s.people[i]
+		}
+	}
+
+	if person == nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_failed", fmt.Sprintf("Person %s not found", email))
+		return
+	}
+
+	member := api.TeamMember{Person: person.Person, TeamLead: body.TeamLead}
+
+	i := memberIndex(t, email)
+	if i < 0 {
+		t.Members = append(t.Members, member)
+		person.Teams = append(person.Teams, t.NamedEntity)
+	} else {
+		t.Members[i] = member
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": member})
+}
+
+func (s *Server) removeMember(w http.ResponseWriter, r *http.Request) {
+	teamID, email := r.PathValue("teamID"), r.PathValue("email")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.teams[teamID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "team_not_found", "Team not found")
+		return
+	}
+
+	i := memberIndex(t, email)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "member_not_found", "Member not found")
+		return
+	}
+
+	t.Members = append(t.Members[:i], t.Members[i+1:]...)
+
+	for i := range s.people {
+		if strings.EqualFold(s.people[i].Email, email) {
+			s.people[i].Teams = slices.DeleteFunc(s.people[i].Teams, func(e api.NamedEntity) bool { return e.ID == teamID })
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func memberIndex(t *api.TeamWithMembers, email string) int {
+	return slices.IndexFunc(t.Members, func(m api.TeamMember) bool { return strings.EqualFold(m.Email, email) })
+}
+
+// checkTeam validates the slug and parent of team teamID, empty for a new
+// team, s.mu held. It returns a zero status when both are acceptable.
+func (s *Server) checkTeam(teamID, slug, parentID string) (int, string, string) {
+	for _, t := range s.teams {
+		if t.ID != teamID && t.Slug == slug {
+			return http.StatusConflict, "conflict", fmt.Sprintf("Slug %s is already taken", slug)
+		}
+	}
+
+	// Walk up from the parent, which must exist and must not be the team itself.
+	for id := parentID; id != ""; {
+		if id == teamID {
+			return http.StatusUnprocessableEntity, "validation_failed", "Team can't be its own ancestor"
+		}
+
+		p, ok := s.teams[id]
+		if !ok {
+			return http.StatusUnprocessableEntity, "validation_failed", fmt.Sprintf("Parent team %s not found", parentID)
+		}
+		id = p.ParentID
+	}
+
+	return 0, "", ""
+}
+
+func slugify(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+func (s *Server) findManifest(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	m, ok := s.manifests[r.PathValue("teamID")]
+	etag := s.etag(r.PathValue("teamID"))
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "manifest_not_found", "Manifest not found")
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	writeJSON(w, http.StatusOK, manifestResponse(m))
+}
+
+func (s *Server) setManifest(w http.ResponseWriter, r *http.Request) {
+	teamID := r.PathValue("teamID")
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+
+	var body api.SetTeamManifestRequest
+	if err := decoder.Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+		return
+	}
+
+	if body.Reference == "" {
+		writeError(w, http.StatusUnprocessableEntity, "validation_failed", "externalReference is required")
+		return
+	}
+
+	s.mu.Lock()
+	t, ok := s.teams[teamID]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "team_not_found", "Team not found")
+		return
+	}
+
+	if !s.matches(r, teamID) {
+		s.mu.Unlock()
+		writeError(w, http.StatusPreconditionFailed, "conflict", "Manifest was modified concurrently")
+		return
+	}
+
+	if _, exists := s.manifests[teamID]; exists && r.Header.Get("If-None-Match") == "*" {
+		s.mu.Unlock()
+		writeError(w, http.StatusPreconditionFailed, "conflict", "Manifest already exists")
+		return
+	}
+
+	m := api.TeamManifest{
+		TeamID:        teamID,
+		TeamName:      t.Name,
+		TeamReference: body.Reference,
+		TechLead:      techLead(t),
+		Vendors:       body.Vendors,
+	}
+	s.manifests[teamID] = m
+	s.versions[teamID]++
+	etag := s.etag(teamID)
+	s.mu.Unlock()
+
+	w.Header().Set("ETag", etag)
+	writeJSON(w, http.StatusOK, manifestResponse(m))
+}
+
+func (s *Server) deleteManifest(w http.ResponseWriter, r *http.Request) {
+	teamID := r.PathValue("teamID")
+
+	s.mu.Lock()
+	_, ok := s.manifests[teamID]
+	matches := s.matches(r, teamID)
+	if ok && matches {
+		delete(s.manifests, teamID)
+		s.versions[teamID]++
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "manifest_not_found", "Manifest not found")
+		return
+	}
+
+	if !matches {
+		writeError(w, http.StatusPreconditionFailed, "conflict", "Manifest was modified concurrently")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// etag returns the entity tag of the manifest of teamID, s.mu held.
+func (s *Server) etag(teamID string) string {
+	return strconv.Quote(strconv.Itoa(s.versions[teamID]))
+}
+
+// matches evaluates the If-Match precondition of r against the manifest of
+// teamID, s.mu held. Requests without If-Match always match.
+func (s *Server) matches(r *http.Request, teamID string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+
+	_, ok := s.manifests[teamID]
+	return ok && ifMatch == s.etag(teamID)
+}
+
+func techLead(t *api.TeamWithMembers) string {
+	for _, m := range t.Members {
+		if m.TeamLead {
+			return m.Email
+		}
+	}
+	return ""
+}
+
+// manifestResponse mirrors the manifest payload of the Span API, keyed by
+// the external reference of the team.
+func manifestResponse(m api.TeamManifest) map[string]any {
+	vendors := m.Vendors
+	if vendors == nil {
+		vendors = map[string]any{}
+	}
+
+	return map[string]any{
+		"data": map[string]any{
+			m.TeamReference: map[string]any{
+				"pretty_name":        m.TeamName,
+				"external_reference": m.TeamReference,
+				"tech_lead":          m.TechLead,
+				"vendors":            vendors,
+			},
+		},
+	}
+}
+
+// writePage serves a page of items, using the numeric offset as cursor.
+func writePage[T any](w http.ResponseWriter, r *http.Request, maxPageSize int, pageNumbers bool, items []T) {
+	query := r.URL.Query()
+
+	size := maxPageSize
+	if v, err := strconv.Atoi(query.Get("pageSize")); err == nil && v > 0 && v < size {
+		size = v
+	}
+
+	var offset int
+	if pageNumbers {
+		page, _ := strconv.Atoi(query.Get("page"))
+		offset = (max(page, 1) - 1) * size
+	} else {
+		offset, _ = strconv.Atoi(query.Get("cursor"))
+	}
+	offset = min(max(offset, 0), len(items))
+	end := min(offset+size, len(items))
+
+	meta := api.Meta{PageSize: size, HasMore: end < len(items)}
+	if pageNumbers {
+		meta.Page = offset/size + 1
+	} else {
+		meta.TotalCount = len(items)
+		if meta.HasMore {
+			meta.NextCursor = strconv.Itoa(end)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"data": items[offset:end], "meta": meta})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]any{"error": map[string]string{"code": code, "message": message}})
+}
+
+// Fault describes a failure served instead of the regular response.
+type Fault struct {
+	// Status is the HTTP status to reply with. Zero keeps the regular
+	// response, which combined with Delay simulates slow responses.
+	Status int
+	// Delay is waited before replying, or until the client gives up.
+	Delay time.Duration
+	// RetryAfter sets the Retry-After header when not empty.
+	RetryAfter string
+	// Times is the number of requests the fault applies to, 0 means always.
+	Times int
+}
+
+// NotFound replies 404 to the next request.
+func NotFound() Fault {
+	return Fault{Status: http.StatusNotFound, Times: 1}
+}
+
+// RateLimited replies 429 with the given Retry-After to the next request.
+func RateLimited(retryAfter time.Duration) Fault {
+	return Fault{Status: http.StatusTooManyRequests, RetryAfter: strconv.Itoa(int(retryAfter.Seconds())), Times: 1}
+}
+
+// ServerError replies 500 to the next request.
+func ServerError() Fault {
+	return Fault{Status: http.StatusInternalServerError, Times: 1}
+}
+
+// Slow delays the next response by d.
+func Slow(d time.Duration) Fault {
+	return Fault{Delay: d, Times: 1}
+}
+
+// InjectFault queues f for the given route. Faults apply in injection order.
+func (s *Server) InjectFault(route string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[route] = append(s.faults[route], &f)
+}
+
+// ClearFaults drops every pending fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = map[string][]*Fault{}
+}
+
+// nextFault pops the fault to apply to the current request, s.mu held.
+func (s *Server) nextFault(route string) *Fault {
+	queue := s.faults[route]
+	if len(queue) == 0 {
+		return nil
+	}
+
+	f := queue[0]
+	if f.Times > 0 {
+		f.Times--
+		if f.Times == 0 {
+			s.faults[route] = queue[1:]
+		}
+	}
+
+	return f
+}
+
+// serve applies the fault and reports whether the response was written.
+func (f *Fault) serve(w http.ResponseWriter, r *http.Request) bool {
+	if f.Delay > 0 {
+		select {
+		case <-time.After(f.Delay):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	if f.Status == 0 {
+		return false
+	}
+
+	if f.RetryAfter != "" {
+		w.Header().Set("Retry-After", f.RetryAfter)
+	}
+
+	writeError(w, f.Status, "injected_fault", http.StatusText(f.Status))
+
+	return true
+}
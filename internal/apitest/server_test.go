@@ -0,0 +1,225 @@
+package apitest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+)
+
+const testToken = "test"
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	srv := NewServer(WithToken(testToken))
+	t.Cleanup(srv.Close)
+
+	ada := api.Person{ID: "p-ada", Email: "ada@example.com", Name: "Ada Lovelace"}
+	srv.AddTeam(api.TeamWithMembers{
+		Team:    api.Team{NamedEntity: api.NamedEntity{ID: "t-platform", Name: "Platform"}, Slug: "platform"},
+		Members: []api.TeamMember{{Person: ada, TeamLead: true}},
+	})
+	srv.AddPerson(api.PersonWithTeam{Person: ada, Teams: []api.NamedEntity{{ID: "t-platform", Name: "Platform"}}})
+	srv.SetManifest("t-platform", api.TeamManifest{TeamName: "Platform", TeamReference: "platform"})
+
+	return srv
+}
+
+// send issues a request to srv with the test token and the given headers,
+// given as name and value pairs, and returns the response and its body.
+func send(t *testing.T, srv *Server, method, path, body string, headers ...string) (*http.Response, string) {
+	t.Helper()
+
+	r, err := http.NewRequest(method, srv.Endpoint()+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("Authorization", "Bearer "+testToken)
+	for i := 0; i+1 < len(headers); i += 2 {
+		r.Header.Set(headers[i], headers[i+1])
+	}
+
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp, string(b)
+}
+
+func TestServerAuth(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, _ := send(t, srv, http.MethodGet, "/catalog/teams", "", "Authorization", "Bearer wrong")
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("wrong token: got status %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	resp, _ = send(t, srv, http.MethodGet, "/catalog/teams", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("valid token: got status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if resp.Header.Get(api.RequestIDHeader) == "" {
+		t.Errorf("missing %s header", api.RequestIDHeader)
+	}
+}
+
+func TestServerRoutes(t *testing.T) {
+	tests := []struct {
+		route    string
+		method   string
+		path     string
+		body     string
+		status   int
+		contains string
+	}{
+		{RouteFindPeople, http.MethodGet, "/catalog/people?email=ada@example.com", "", http.StatusOK, `"p-ada"`},
+		{RouteFindTeams, http.MethodGet, "/catalog/teams?slug=platform", "", http.StatusOK, `"t-platform"`},
+		{RouteFindTeam, http.MethodGet, "/catalog/teams/t-platform", "", http.StatusOK, `"ada@example.com"`},
+		{RouteFindTeam, http.MethodGet, "/catalog/teams/t-missing", "", http.StatusNotFound, `"team_not_found"`},
+		{RouteCreateTeam, http.MethodPost, "/catalog/teams", `{"name": "Site Reliability"}`, http.StatusCreated, `"site-reliability"`},
+		{RouteCreateTeam, http.MethodPost, "/catalog/teams", `{"name": "Core", "parentId": "t-missing"}`, http.StatusUnprocessableEntity, `"validation_failed"`},
+		{RouteUpdateTeam, http.MethodPatch, "/catalog/teams/t-platform", `{"name": "Platform Engineering"}`, http.StatusOK, `"Platform Engineering"`},
+		{RouteUpdateTeam, http.MethodPatch, "/catalog/teams/t-platform", `{"name": "Platform", "parentId": "t-platform"}`, http.StatusUnprocessableEntity, `"validation_failed"`},
+		{RouteSetMember, http.MethodPut, "/catalog/teams/t-platform/members/ada@example.com", `{"teamLead": false}`, http.StatusOK, `"ada@example.com"`},
+		{RouteRemoveMember, http.MethodDelete, "/catalog/teams/t-platform/members/nobody@example.com", "", http.StatusNotFound, ""},
+		{RouteFindManifest, http.MethodGet, "/catalog/teams/t-platform/manifest", "", http.StatusOK, `"external_reference":"platform"`},
+		{RouteSetManifest, http.MethodPost, "/catalog/teams/t-platform/manifest", `{}`, http.StatusUnprocessableEntity, `"externalReference is required"`},
+		{RouteDeleteManifest, http.MethodDelete, "/catalog/teams/t-missing/manifest", "", http.StatusNotFound, `"manifest_not_found"`},
+		{RouteDeleteTeam, http.MethodDelete, "/catalog/teams/t-platform", "", http.StatusNoContent, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			srv := newTestServer(t)
+
+			resp, body := send(t, srv, tt.method, tt.path, tt.body)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("got status %d, want %d: %s", resp.StatusCode, tt.status, body)
+			}
+			if !strings.Contains(body, tt.contains) {
+				t.Errorf("body %s doesn't contain %s", body, tt.contains)
+			}
+			if got := srv.Calls(tt.route); got != 1 {
+				t.Errorf("%s: got %d calls, want 1", tt.route, got)
+			}
+		})
+	}
+}
+
+func TestServerManifestPreconditions(t *testing.T) {
+	srv := newTestServer(t)
+	const path = "/catalog/teams/t-platform/manifest"
+	const body = `{"externalReference": "platform"}`
+
+	steps := []struct {
+		method   string
+		headers  []string
+		status   int
+		wantETag string
+	}{
+		{method: http.MethodGet, status: http.StatusOK, wantETag: `"1"`},
+		{method: http.MethodPost, headers: []string{"If-None-Match", "*"}, status: http.StatusPreconditionFailed},
+		{method: http.MethodPost, headers: []string{"If-Match", `"1"`}, status: http.StatusOK, wantETag: `"2"`},
+		{method: http.MethodPost, headers: []string{"If-Match", `"1"`}, status: http.StatusPreconditionFailed},
+		{method: http.MethodPost, headers: []string{"If-Match", "*"}, status: http.StatusOK, wantETag: `"3"`},
+		{method: http.MethodPost, status: http.StatusOK, wantETag: `"4"`},
+		{method: http.MethodDelete, headers: []string{"If-Match", `"3"`}, status: http.StatusPreconditionFailed},
+		{method: http.MethodDelete, headers: []string{"If-Match", `"4"`}, status: http.StatusNoContent},
+		{method: http.MethodDelete, status: http.StatusNotFound},
+		{method: http.MethodPost, headers: []string{"If-Match", `"5"`}, status: http.StatusPreconditionFailed},
+		{method: http.MethodPost, headers: []string{"If-None-Match", "*"}, status: http.StatusOK, wantETag: `"6"`},
+	}
+
+	for i, s := range steps {
+		var reqBody string
+		if s.method == http.MethodPost {
+			reqBody = body
+		}
+
+		resp, respBody := send(t, srv, s.method, path, reqBody, s.headers...)
+		if resp.StatusCode != s.status {
+			t.Fatalf("step %d, %s %v: got status %d, want %d: %s", i, s.method, s.headers, resp.StatusCode, s.status, respBody)
+		}
+		if got := resp.Header.Get("ETag"); got != s.wantETag {
+			t.Errorf("step %d, %s %v: got ETag %s, want %s", i, s.method, s.headers, got, s.wantETag)
+		}
+	}
+
+	if _, ok := srv.Manifest("t-platform"); !ok {
+		t.Error("manifest missing after the last write")
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	srv := newTestServer(t)
+	const path = "/catalog/teams/t-platform"
+
+	srv.InjectFault(RouteFindTeam, NotFound())
+	srv.InjectFault(RouteFindTeam, RateLimited(2*time.Second))
+	srv.InjectFault(RouteFindTeam, ServerError())
+
+	want := []struct {
+		status     int
+		retryAfter string
+	}{
+		{status: http.StatusNotFound},
+		{status: http.StatusTooManyRequests, retryAfter: "2"},
+		{status: http.StatusInternalServerError},
+		{status: http.StatusOK},
+	}
+	for i, w := range want {
+		resp, _ := send(t, srv, http.MethodGet, path, "")
+		if resp.StatusCode != w.status {
+			t.Errorf("request %d: got status %d, want %d", i, resp.StatusCode, w.status)
+		}
+		if got := resp.Header.Get("Retry-After"); got != w.retryAfter {
+			t.Errorf("request %d: got Retry-After %q, want %q", i, got, w.retryAfter)
+		}
+	}
+
+	// Faults are scoped to their route.
+	if resp, _ := send(t, srv, http.MethodGet, "/catalog/teams", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("other route: got status %d", resp.StatusCode)
+	}
+
+	// Persistent faults apply until cleared.
+	srv.InjectFault(RouteFindTeam, Fault{Status: http.StatusBadGateway})
+	for range 3 {
+		if resp, _ := send(t, srv, http.MethodGet, path, ""); resp.StatusCode != http.StatusBadGateway {
+			t.Errorf("persistent fault: got status %d", resp.StatusCode)
+		}
+	}
+	srv.ClearFaults()
+	if resp, _ := send(t, srv, http.MethodGet, path, ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("cleared faults: got status %d", resp.StatusCode)
+	}
+
+	if got := srv.Calls(RouteFindTeam); got != 8 {
+		t.Errorf("got %d calls, want 8", got)
+	}
+}
+
+func TestServerSlow(t *testing.T) {
+	srv := newTestServer(t)
+	srv.InjectFault(RouteFindTeam, Slow(50*time.Millisecond))
+
+	start := time.Now()
+	resp, body := send(t, srv, http.MethodGet, "/catalog/teams/t-platform", "")
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("replied after %s, want at least 50ms", elapsed)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, `"Platform"`) {
+		t.Errorf("got status %d: %s", resp.StatusCode, body)
+	}
+}
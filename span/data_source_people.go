@@ -3,11 +3,13 @@ package span
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/attuned-corp/terraform-provider-span/internal/api"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -18,7 +20,73 @@ func NewPeopleDataSource() datasource.DataSource {
 }
 
 type PeopleResourceData struct {
-	People types.List `tfsdk:"people"`
+	TeamIDs      types.Set    `tfsdk:"team_ids"`
+	Emails       types.Set    `tfsdk:"emails"`
+	NameContains types.String `tfsdk:"name_contains"`
+	Limit        types.Int64  `tfsdk:"limit"`
+	People       types.List   `tfsdk:"people"`
+}
+
+// peopleFilter holds the configured filters which the API can't apply.
+type peopleFilter struct {
+	emails       map[string]bool
+	nameContains string
+}
+
+func (f peopleFilter) empty() bool {
+	return len(f.emails) == 0 && f.nameContains == ""
+}
+
+func (f peopleFilter) matches(p *api.PersonWithTeam) bool {
+	if len(f.emails) > 0 && !f.emails[strings.ToLower(p.Email)] {
+		return false
+	}
+
+	if f.nameContains != "" && !strings.Contains(strings.ToLower(p.Name), f.nameContains) {
+		return false
+	}
+
+	return true
+}
+
+// request splits the configured filters between the API request and the
+// ones which have to be applied on the client.
+func (pr PeopleResourceData) request(ctx context.Context, diags *diag.Diagnostics) (api.FindPeopleRequest, peopleFilter) {
+	var (
+		r      api.FindPeopleRequest
+		f      peopleFilter
+		emails []string
+	)
+
+	if !pr.TeamIDs.IsNull() {
+		diags.Append(pr.TeamIDs.ElementsAs(ctx, &r.TeamIDs, false)...)
+	}
+
+	if !pr.Emails.IsNull() {
+		diags.Append(pr.Emails.ElementsAs(ctx, &emails, false)...)
+	}
+
+	// The API filters a single email only. Emails are always matched locally
+	// too, as matching must be case insensitive whatever the API does.
+	if len(emails) == 1 {
+		r.Email = emails[0]
+	}
+
+	if len(emails) > 0 {
+		f.emails = make(map[string]bool, len(emails))
+		for _, email := range emails {
+			f.emails[strings.ToLower(email)] = true
+		}
+	}
+
+	f.nameContains = strings.ToLower(pr.NameContains.ValueString())
+
+	// The limit can only be pushed down when no filtering is left to do.
+	if f.empty() {
+		r.Limit = int(pr.Limit.ValueInt64())
+	}
+
+	return r, f
 }
 
 // PersonDataSource is the concrete implementation
@@ -34,9 +102,27 @@ func (d *PeopleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A data source representation of multiple people within Span.",
 		Attributes: map[string]schema.Attribute{
+			"team_ids": schema.SetAttribute{
+				MarkdownDescription: "Optional set of team ids, only members of any of these teams are returned.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"emails": schema.SetAttribute{
+				MarkdownDescription: "Optional set of emails to select. Matching is case insensitive.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"name_contains": schema.StringAttribute{
+				MarkdownDescription: "Optional case insensitive substring the name of each person must contain.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Optional maximum number of people to return. All pages are loaded when unset.",
+				Optional:            true,
+			},
 			"people": schema.ListNestedAttribute{
 				Computed:            true,
-				MarkdownDescription: "Complete list of people within Span.",
+				MarkdownDescription: "List of people within Span matching the given filters.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: PersonResourceData{}.Attributes(),
 				},
@@ -65,10 +151,6 @@ func (d *PeopleDataSource) Configure(_ context.Context, req datasource.Configure
 func newPeopleResourceData(ctx context.Context, in []api.PersonWithTeam, diags *diag.Diagnostics) PeopleResourceData {
 	var data PeopleResourceData
 
-	if len(in) == 0 {
-		return data
-	}
-
 	people := make([]PersonResourceData, len(in))
 	for i, incoming := range in {
 		people[i] = newPersonResourceData(ctx, &incoming)
@@ -93,13 +175,42 @@ func (d *PeopleDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	response, err := d.apiClient.FindPeople(api.FindPeopleRequest{})
+	if !data.Limit.IsNull() && data.Limit.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("limit"), "Invalid limit", "The limit must be a positive number.")
+		return
+	}
+
+	request, filter := data.request(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := d.apiClient.FindPeople(ctx, request)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected API error", fmt.Sprintf("Raw: %s\n", err.Error()))
+		addAPIError(&resp.Diagnostics, err)
 		return
 	}
 
+	if !filter.empty() {
+		filtered := make([]api.PersonWithTeam, 0, len(response))
+		for _, person := range response {
+			if filter.matches(&person) {
+				filtered = append(filtered, person)
+			}
+		}
+		response = filtered
+	}
+
+	if limit := int(data.Limit.ValueInt64()); limit > 0 && len(response) > limit {
+		response = response[:limit]
+	}
+
+	config := data
 	data = newPeopleResourceData(ctx, response, &resp.Diagnostics)
+	data.TeamIDs = config.TeamIDs
+	data.Emails = config.Emails
+	data.NameContains = config.NameContains
+	data.Limit = config.Limit
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
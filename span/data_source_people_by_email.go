@@ -0,0 +1,217 @@
+package span
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &PeopleByEmailDataSource{}
+
+func NewPeopleByEmailDataSource() datasource.DataSource {
+	return &PeopleByEmailDataSource{}
+}
+
+// PeopleByEmailDataSource resolves a set of emails to people in bulk.
+type PeopleByEmailDataSource struct {
+	apiClient api.SpanAPIClient
+}
+
+type PeopleByEmailResourceData struct {
+	Emails        types.Set  `tfsdk:"emails"`
+	FailOnMissing types.Bool `tfsdk:"fail_on_missing"`
+	Found         types.Map  `tfsdk:"found"`
+	Missing       types.List `tfsdk:"missing"`
+}
+
+func (d *PeopleByEmailDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_people_by_email"
+}
+
+func (d *PeopleByEmailDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves a set of emails to people within Span in as few requests as possible. " +
+			"Unlike `span_person`, emails without a matching person are reported in `missing` rather than failing the plan.",
+		Attributes: map[string]schema.Attribute{
+			"emails": schema.SetAttribute{
+				MarkdownDescription: "Emails of the people to look up. Matching is case insensitive.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"fail_on_missing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fail when any email has no matching person. Defaults to `false`.",
+				Optional:            true,
+			},
+			"found": schema.MapNestedAttribute{
+				MarkdownDescription: "People found, keyed by the email as given in `emails`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: PersonResourceData{}.Attributes(),
+				},
+			},
+			"missing": schema.ListAttribute{
+				MarkdownDescription: "Sorted emails without a matching person.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PeopleByEmailDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(api.SpanAPIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configuration Type",
+			fmt.Sprintf("Expected a SpanAPIClient but got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.apiClient = apiClient
+}
+
+// findPeopleByEmail returns the people matching emails keyed by lower cased
+// email, picking whichever of per-email lookups or a listing of every person
+// needs fewer requests. The listing takes one request per page, which is
+// estimated from the total count reported with its first page. People found
+// on that first page need no further request.
+func (d *PeopleByEmailDataSource) findPeopleByEmail(ctx context.Context, emails []string) (map[string]api.PersonWithTeam, error) {
+	wanted := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		wanted[strings.ToLower(email)] = true
+	}
+
+	found := make(map[string]api.PersonWithTeam, len(wanted))
+	collect := func(people []api.PersonWithTeam) {
+		for _, p := range people {
+			if email := strings.ToLower(p.Email); wanted[email] {
+				found[email] = p
+			}
+		}
+	}
+
+	// A single email is never cheaper to find by listing.
+	if len(wanted) > 1 {
+		first, err := d.apiClient.FindPeoplePage(ctx, api.FindPeopleRequest{})
+		if err != nil {
+			return nil, err
+		}
+		collect(first.Data)
+
+		if len(found) == len(wanted) || !hasMorePages(first) {
+			return found, nil
+		}
+
+		if pages, ok := countPages(first); ok && pages <= len(wanted)-len(found) {
+			people, err := d.apiClient.FindPeople(ctx, api.FindPeopleRequest{})
+			if err != nil {
+				return nil, err
+			}
+			collect(people)
+			return found, nil
+		}
+	}
+
+	for email := range wanted {
+		if _, ok := found[email]; ok {
+			continue
+		}
+
+		people, err := d.apiClient.FindPeople(ctx, api.FindPeopleRequest{Email: email})
+		if err != nil {
+			return nil, err
+		}
+		collect(people)
+	}
+
+	return found, nil
+}
+
+// hasMorePages reports whether a listing continues past its first page.
+func hasMorePages(first *api.FindPeopleResponse) bool {
+	meta := first.Meta
+	return meta.HasMore || meta.NextCursor != "" || meta.TotalCount > len(first.Data)
+}
+
+// countPages estimates the number of pages of a listing from its first one,
+// which fails when Span doesn't report the total count.
+func countPages(first *api.FindPeopleResponse) (int, bool) {
+	if first.Meta.TotalCount <= 0 || len(first.Data) == 0 {
+		return 0, false
+	}
+
+	size := len(first.Data)
+	return (first.Meta.TotalCount + size - 1) / size, true
+}
+
+func (d *PeopleByEmailDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PeopleByEmailResourceData
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var emails []string
+	resp.Diagnostics.Append(data.Emails.ElementsAs(ctx, &emails, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	people, err := d.findPeopleByEmail(ctx, emails)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	found := make(map[string]PersonResourceData, len(people))
+	missing := []string{}
+	for _, email := range emails {
+		person, ok := people[strings.ToLower(email)]
+		if !ok {
+			missing = append(missing, email)
+			continue
+		}
+		found[email] = newPersonResourceData(ctx, &person)
+	}
+
+	sort.Strings(missing)
+
+	if data.FailOnMissing.ValueBool() && len(missing) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("emails"), "Missing data source",
+			fmt.Sprintf("Could not load data source for people with emails %s", strings.Join(missing, ", ")))
+		return
+	}
+
+	data.Found = newPeopleMap(ctx, found, &resp.Diagnostics)
+
+	missingList, diags := types.ListValueFrom(ctx, types.StringType, missing)
+	resp.Diagnostics.Append(diags...)
+	data.Missing = missingList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func newPeopleMap(ctx context.Context, in map[string]PersonResourceData, diags *diag.Diagnostics) types.Map {
+	result, d := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: PersonResourceData{}.AttrTypes()}, in)
+
+	diags.Append(d...)
+
+	return result
+}
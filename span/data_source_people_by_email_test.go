@@ -0,0 +1,82 @@
+package span
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPeopleByEmailDataSource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_people_by_email" "test" {
+  emails = ["Ada@example.com", "nobody@example.com"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_people_by_email.test", "found.%", "1"),
+					resource.TestCheckResourceAttr("data.span_people_by_email.test", "found.Ada@example.com.id", "p-ada"),
+					resource.TestCheckResourceAttr("data.span_people_by_email.test", "missing.#", "1"),
+					resource.TestCheckResourceAttr("data.span_people_by_email.test", "missing.0", "nobody@example.com"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_people_by_email" "test" {
+  emails          = ["ada@example.com", "nobody@example.com"]
+  fail_on_missing = true
+}
+`,
+				ExpectError: regexp.MustCompile("nobody@example.com"),
+			},
+		},
+	})
+}
+
+func TestFindPeopleByEmail(t *testing.T) {
+	tests := []struct {
+		name   string
+		emails []string
+		found  int
+		// calls is the number of requests sent, with one person per page.
+		calls int
+	}{
+		{name: "single email", emails: []string{"grace@example.com"}, found: 1, calls: 1},
+		{name: "all on first page", emails: []string{"ADA@example.com", "ada@example.com"}, found: 1, calls: 1},
+		{name: "fewer lookups than pages", emails: []string{"ada@example.com", "grace@example.com"}, found: 2, calls: 1 + 1},
+		{name: "fewer pages than lookups", emails: []string{"ada@example.com", "grace@example.com", "nobody@example.com"}, found: 2, calls: 1 + 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, apitest.WithPageSize(1))
+
+			client, err := api.NewSpanAPIClient(api.WithEndpoint(srv.Endpoint()), api.WithToken(testAccToken))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := &PeopleByEmailDataSource{apiClient: client}
+			found, err := d.findPeopleByEmail(context.Background(), tt.emails)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(found) != tt.found {
+				t.Errorf("found %d people, want %d", len(found), tt.found)
+			}
+			if calls := srv.Calls(apitest.RouteFindPeople); calls != tt.calls {
+				t.Errorf("sent %d requests, want %d", calls, tt.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package span
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPersonDataSource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_person" "by_email" {
+  email = "ADA@example.com"
+}
+
+data "span_person" "by_github_login" {
+  github_login = "grace"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_person.by_email", "id", "p-ada"),
+					resource.TestCheckResourceAttr("data.span_person.by_email", "name", "Ada Lovelace"),
+					resource.TestCheckResourceAttr("data.span_person.by_email", "title", "Staff Engineer"),
+					resource.TestCheckNoResourceAttr("data.span_person.by_email", "manager_email"),
+					resource.TestCheckResourceAttr("data.span_person.by_email", "teams.#", "1"),
+					resource.TestCheckResourceAttr("data.span_person.by_email", "teams.0.id", "t-platform"),
+					resource.TestCheckResourceAttr("data.span_person.by_github_login", "email", "grace@example.com"),
+					resource.TestCheckResourceAttr("data.span_person.by_github_login", "manager_email", "ada@example.com"),
+					resource.TestCheckResourceAttr("data.span_person.by_github_login", "teams.#", "2"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_person" "missing" {
+  email = "nobody@example.com"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing data source"),
+			},
+		},
+	})
+}
+
+func TestAccPeopleDataSource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_people" "engineering" {
+  team_ids = ["t-eng"]
+}
+
+data "span_people" "by_email" {
+  emails = ["Ada@Example.com", "grace@example.com"]
+}
+
+data "span_people" "by_name" {
+  name_contains = "hopper"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_people.engineering", "people.#", "1"),
+					resource.TestCheckResourceAttr("data.span_people.engineering", "people.0.email", "grace@example.com"),
+					resource.TestCheckResourceAttr("data.span_people.by_email", "people.#", "2"),
+					resource.TestCheckResourceAttr("data.span_people.by_name", "people.#", "1"),
+					resource.TestCheckResourceAttr("data.span_people.by_name", "people.0.id", "p-grace"),
+				),
+			},
+		},
+	})
+}
@@ -3,16 +3,22 @@ package span
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &PersonDataSource{}
+var (
+	_ datasource.DataSource                     = &PersonDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &PersonDataSource{}
+)
 
 func NewPersonDataSource() datasource.DataSource {
 	return &PersonDataSource{}
@@ -24,20 +30,53 @@ type PersonDataSource struct {
 }
 
 type PersonResourceData struct {
-	Email types.String `tfsdk:"email"`
-	Name  types.String `tfsdk:"name"`
-	Teams types.List   `tfsdk:"teams"`
+	ID           types.String `tfsdk:"id"`
+	Email        types.String `tfsdk:"email"`
+	Name         types.String `tfsdk:"name"`
+	GitHubLogin  types.String `tfsdk:"github_login"`
+	Title        types.String `tfsdk:"title"`
+	ManagerEmail types.String `tfsdk:"manager_email"`
+	SlackHandle  types.String `tfsdk:"slack_handle"`
+	Active       types.Bool   `tfsdk:"active"`
+	Teams        types.List   `tfsdk:"teams"`
 }
 
 func (pr PersonResourceData) Attributes() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "ID of the person within Span.",
+			Computed:            true,
+		},
 		"email": schema.StringAttribute{
-			MarkdownDescription: "The email for the specific person",
+			MarkdownDescription: "The email for the specific person. Matching is case insensitive.",
 			Optional:            true,
+			Computed:            true,
 		},
 		"name": schema.StringAttribute{
-			MarkdownDescription: "Full name of the person.",
+			MarkdownDescription: "Full name of the person. Must match a single person.",
 			Optional:            true,
+			Computed:            true,
+		},
+		"github_login": schema.StringAttribute{
+			MarkdownDescription: "GitHub login of the person. Matching is case insensitive.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"title": schema.StringAttribute{
+			MarkdownDescription: "Job title of the person.",
+			Computed:            true,
+		},
+		"manager_email": schema.StringAttribute{
+			MarkdownDescription: "Email of the manager of the person.",
+			Computed:            true,
+		},
+		"slack_handle": schema.StringAttribute{
+			MarkdownDescription: "Slack handle of the person.",
+			Computed:            true,
+		},
+		"active": schema.BoolAttribute{
+			MarkdownDescription: "Whether the person is active, null when Span does not report it.",
+			Computed:            true,
 		},
 		"teams": schema.ListNestedAttribute{
 			Computed:            true,
@@ -58,9 +97,15 @@ func (pr PersonResourceData) Attributes() map[string]schema.Attribute {
 
 func (pr PersonResourceData) AttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"email": types.StringType,
-		"name":  types.StringType,
-		"teams": types.ListType{ElemType: types.ObjectType{AttrTypes: PersonTeam{}.AttrTypes()}},
+		"id":            types.StringType,
+		"email":         types.StringType,
+		"name":          types.StringType,
+		"github_login":  types.StringType,
+		"title":         types.StringType,
+		"manager_email": types.StringType,
+		"slack_handle":  types.StringType,
+		"active":        types.BoolType,
+		"teams":         types.ListType{ElemType: types.ObjectType{AttrTypes: PersonTeam{}.AttrTypes()}},
 	}
 }
 
@@ -100,13 +145,28 @@ func newPersonResourceData(ctx context.Context, in *api.PersonWithTeam) PersonRe
 	// @TODO: Diagnostics handling for unmarshal
 	var d diag.Diagnostics
 
+	data.ID = stringValueOrNull(in.ID)
 	data.Email = types.StringValue(in.Email)
 	data.Name = types.StringValue(in.Name)
+	data.GitHubLogin = stringValueOrNull(in.GitHubLogin)
+	data.Title = stringValueOrNull(in.Title)
+	data.ManagerEmail = stringValueOrNull(in.ManagerEmail)
+	data.SlackHandle = stringValueOrNull(in.SlackHandle)
+	data.Active = types.BoolPointerValue(in.Active)
 	data.Teams = newPersonTeamList(ctx, in.Teams, &d)
 
 	return data
 }
 
+// stringValueOrNull maps the empty strings Span sends for unset profile
+// fields to null.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
 func (d *PersonDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_person"
 }
@@ -118,6 +178,16 @@ func (d *PersonDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 	}
 }
 
+func (d *PersonDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("email"),
+			path.MatchRoot("name"),
+			path.MatchRoot("github_login"),
+		),
+	}
+}
+
 func (d *PersonDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -145,28 +215,87 @@ func (d *PersonDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	if data.Email.IsNull() {
-		resp.Diagnostics.AddError("Missing required parameter for person data source loading...", "")
+	lookup, ok := data.lookup()
+	if !ok {
+		resp.Diagnostics.AddError("Missing required parameter for person loading - 'email', 'name' or 'github_login'...", "")
 		return
 	}
 
-	response, err := d.apiClient.FindPeople(api.FindPeopleRequest{Email: data.Email.ValueString()})
+	response, err := d.apiClient.FindPeople(ctx, lookup.request)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected API error", fmt.Sprintf("Raw: %s\n", err.Error()))
+		addAPIError(&resp.Diagnostics, err)
 		return
 	}
 
-	if len(response) == 0 {
-		resp.Diagnostics.AddError("Missing data source", fmt.Sprintf("Could not load data source for person with email %s", data.Email.ValueString()))
+	matches := lookup.filter(response)
+
+	if len(matches) == 0 {
+		resp.Diagnostics.AddError("Missing data source", fmt.Sprintf("Could not load data source for person with %s %s", lookup.attribute, lookup.value))
 		return
 	}
 
-	if len(response) > 1 {
-		resp.Diagnostics.AddError("Multiple matches found where single result expected", fmt.Sprintf("Multiple results for user with e-mail %s", data.Email.ValueString()))
+	if len(matches) > 1 {
+		emails := make([]string, len(matches))
+		for i, p := range matches {
+			emails[i] = p.Email
+		}
+		resp.Diagnostics.AddError("Multiple matches found where single result expected",
+			fmt.Sprintf("Multiple results for person with %s %s: %s", lookup.attribute, lookup.value, strings.Join(emails, ", ")))
 		return
 	}
 
-	data = newPersonResourceData(ctx, &response[0])
+	data = newPersonResourceData(ctx, &matches[0])
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// personLookup is the identifier a single person is looked up by.
+type personLookup struct {
+	attribute string
+	value     string
+	request   api.FindPeopleRequest
+}
+
+func (pr PersonResourceData) lookup() (personLookup, bool) {
+	switch {
+	case !pr.Email.IsNull():
+		value := pr.Email.ValueString()
+		return personLookup{"email", value, api.FindPeopleRequest{Email: value}}, true
+	case !pr.Name.IsNull():
+		value := pr.Name.ValueString()
+		return personLookup{"name", value, api.FindPeopleRequest{Name: value}}, true
+	case !pr.GitHubLogin.IsNull():
+		value := pr.GitHubLogin.ValueString()
+		return personLookup{"github_login", value, api.FindPeopleRequest{GitHubLogin: value}}, true
+	}
+	return personLookup{}, false
+}
+
+// filter keeps the people matching the lookup, as the API may match loosely
+// or ignore filters it does not support. Names are compared exactly, falling
+// back to a case insensitive match when no name matches exactly.
+func (l personLookup) filter(people []api.PersonWithTeam) []api.PersonWithTeam {
+	var exact, folded []api.PersonWithTeam
+	for _, p := range people {
+		var candidate string
+		switch l.attribute {
+		case "email":
+			candidate = p.Email
+		case "name":
+			candidate = p.Name
+		case "github_login":
+			candidate = p.GitHubLogin
+		}
+
+		if l.attribute == "name" && candidate == l.value {
+			exact = append(exact, p)
+		} else if strings.EqualFold(candidate, l.value) {
+			folded = append(folded, p)
+		}
+	}
+
+	if len(exact) > 0 {
+		return exact
+	}
+	return folded
+}
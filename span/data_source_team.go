@@ -3,16 +3,22 @@ package span
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ datasource.DataSource = &TeamDataSource{}
+var (
+	_ datasource.DataSource                     = &TeamDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &TeamDataSource{}
+)
 
 func NewTeamDataSource() datasource.DataSource {
 	return &TeamDataSource{}
@@ -38,9 +44,11 @@ func (pt TeamMember) AttrTypes() map[string]attr.Type {
 }
 
 type TeamResourceData struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
-	Slug types.String `tfsdk:"slug"`
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Slug      types.String `tfsdk:"slug"`
+	ParentID  types.String `tfsdk:"parent_id"`
+	CreatedAt types.String `tfsdk:"created_at"`
 }
 
 func (tr TeamResourceData) Attributes() map[string]schema.Attribute {
@@ -48,29 +56,49 @@ func (tr TeamResourceData) Attributes() map[string]schema.Attribute {
 		"id": schema.StringAttribute{
 			MarkdownDescription: "Immutable ID for the Span team resource",
 			Optional:            true,
+			Computed:            true,
 		},
 		"name": schema.StringAttribute{
 			MarkdownDescription: "Name of the team.",
 			Optional:            true,
+			Computed:            true,
 		},
 		"slug": schema.StringAttribute{
 			MarkdownDescription: "URL friendly unique slug for the team.",
 			Optional:            true,
+			Computed:            true,
+		},
+		"parent_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the parent team, null for top level teams.",
+			Computed:            true,
+		},
+		"created_at": schema.StringAttribute{
+			MarkdownDescription: "Creation timestamp of the team in RFC3339 format.",
+			Computed:            true,
 		},
 	}
 }
 
 func (tr TeamResourceData) AttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"id":   types.StringType,
-		"name": types.StringType,
-		"slug": types.StringType,
+		"id":         types.StringType,
+		"name":       types.StringType,
+		"slug":       types.StringType,
+		"parent_id":  types.StringType,
+		"created_at": types.StringType,
 	}
 }
 
 type TeamDetailsResourceData struct {
 	TeamResourceData
-	Members types.List `tfsdk:"members"`
+	Members      types.List  `tfsdk:"members"`
+	MemberCount  types.Int64 `tfsdk:"member_count"`
+	LeadEmails   types.Set   `tfsdk:"lead_emails"`
+	MemberEmails types.Set   `tfsdk:"member_emails"`
+	Children     types.List  `tfsdk:"children"`
+	Ancestors    types.List  `tfsdk:"ancestors"`
+
+	IncludeHierarchy types.Bool `tfsdk:"include_hierarchy"`
 }
 
 func (pr TeamDetailsResourceData) Attributes() map[string]schema.Attribute {
@@ -92,6 +120,39 @@ func (pr TeamDetailsResourceData) Attributes() map[string]schema.Attribute {
 			},
 		},
 	}
+	trAttributes["member_count"] = schema.Int64Attribute{
+		MarkdownDescription: "Number of members within the team.",
+		Computed:            true,
+	}
+	trAttributes["lead_emails"] = schema.SetAttribute{
+		MarkdownDescription: "Emails of the team leads.",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+	trAttributes["member_emails"] = schema.SetAttribute{
+		MarkdownDescription: "Emails of every team member, leads included.",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+	trAttributes["include_hierarchy"] = schema.BoolAttribute{
+		MarkdownDescription: "Whether to populate `children` and `ancestors`. This lists every team within Span on each read, " +
+			"which takes one request per page of teams unless the provider `cache` is enabled. Defaults to `false`.",
+		Optional: true,
+	}
+	trAttributes["children"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Direct child teams, ordered by name. Null unless `include_hierarchy` is set.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: TeamResourceData{}.Attributes(),
+		},
+	}
+	trAttributes["ancestors"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Parent team, its parent and so on up to the top level team. Null unless `include_hierarchy` is set.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: TeamResourceData{}.Attributes(),
+		},
+	}
 
 	return trAttributes
 }
@@ -99,6 +160,12 @@ func (pr TeamDetailsResourceData) Attributes() map[string]schema.Attribute {
 func (pr TeamDetailsResourceData) AttrTypes() map[string]attr.Type {
 	trAttrTypes := TeamResourceData{}.AttrTypes()
 	trAttrTypes["members"] = types.ListType{ElemType: types.ObjectType{AttrTypes: TeamMember{}.AttrTypes()}}
+	trAttrTypes["member_count"] = types.Int64Type
+	trAttrTypes["lead_emails"] = types.SetType{ElemType: types.StringType}
+	trAttrTypes["member_emails"] = types.SetType{ElemType: types.StringType}
+	trAttrTypes["children"] = types.ListType{ElemType: types.ObjectType{AttrTypes: TeamResourceData{}.AttrTypes()}}
+	trAttrTypes["ancestors"] = types.ListType{ElemType: types.ObjectType{AttrTypes: TeamResourceData{}.AttrTypes()}}
+	trAttrTypes["include_hierarchy"] = types.BoolType
 	return trAttrTypes
 }
 
@@ -127,20 +194,69 @@ func newTeamResourceData(_ context.Context, in *api.Team) TeamResourceData {
 	data.ID = types.StringValue(in.ID)
 	data.Name = types.StringValue(in.Name)
 	data.Slug = types.StringValue(in.Slug)
+	data.ParentID = types.StringNull()
+	data.CreatedAt = types.StringNull()
+
+	if in.ParentID != "" {
+		data.ParentID = types.StringValue(in.ParentID)
+	}
+
+	if !in.CreatedAt.IsZero() {
+		data.CreatedAt = types.StringValue(in.CreatedAt.Format(time.RFC3339))
+	}
 
 	return data
 }
 
-func newTeamDetailsResourceData(ctx context.Context, in *api.TeamWithMembers) TeamDetailsResourceData {
+// newTeamList maps teams onto a list of team objects.
+func newTeamList(ctx context.Context, in []api.Team, diags *diag.Diagnostics) types.List {
+	teams := make([]TeamResourceData, len(in))
+	for i, incoming := range in {
+		teams[i] = newTeamResourceData(ctx, &incoming)
+	}
+
+	result, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: TeamResourceData{}.AttrTypes()}, teams)
+
+	diags.Append(d...)
+
+	return result
+}
+
+// newTeamDetailsResourceData maps a team onto the data source model. Children
+// and ancestors are left null when hierarchy is nil.
+func newTeamDetailsResourceData(ctx context.Context, in *api.TeamWithMembers, hierarchy *api.TeamHierarchy) TeamDetailsResourceData {
 	var data TeamDetailsResourceData
 
 	// @TODO: Diagnostics handling for unmarshal
 	var d diag.Diagnostics
 
-	data.ID = types.StringValue(in.ID)
-	data.Name = types.StringValue(in.Name)
-	data.Slug = types.StringValue(in.Slug)
+	data.TeamResourceData = newTeamResourceData(ctx, &in.Team)
 	data.Members = newTeamMembers(ctx, in.Members, &d)
+	data.MemberCount = types.Int64Value(int64(len(in.Members)))
+
+	leadEmails := []string{}
+	memberEmails := make([]string, 0, len(in.Members))
+	for _, member := range in.Members {
+		memberEmails = append(memberEmails, member.Email)
+		if member.TeamLead {
+			leadEmails = append(leadEmails, member.Email)
+		}
+	}
+
+	var setDiags diag.Diagnostics
+	data.LeadEmails, setDiags = types.SetValueFrom(ctx, types.StringType, leadEmails)
+	d.Append(setDiags...)
+	data.MemberEmails, setDiags = types.SetValueFrom(ctx, types.StringType, memberEmails)
+	d.Append(setDiags...)
+
+	data.Children = types.ListNull(types.ObjectType{AttrTypes: TeamResourceData{}.AttrTypes()})
+	data.Ancestors = types.ListNull(types.ObjectType{AttrTypes: TeamResourceData{}.AttrTypes()})
+	data.IncludeHierarchy = types.BoolNull()
+
+	if hierarchy != nil {
+		data.Children = newTeamList(ctx, hierarchy.Children(in.ID), &d)
+		data.Ancestors = newTeamList(ctx, hierarchy.Ancestors(in.ID), &d)
+	}
 
 	return data
 }
@@ -151,8 +267,19 @@ func (d *TeamDataSource) Metadata(_ context.Context, req datasource.MetadataRequ
 
 func (d *TeamDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "A data source representation for a team within span.",
-		Attributes:          TeamDetailsResourceData{}.Attributes(),
+		MarkdownDescription: "A data source representation for a team within span, optionally along with its position within the team hierarchy. " +
+			"The team is looked up by `slug`, `name` or `id`, in that order of precedence, and must match every one of them that is set.",
+		Attributes: TeamDetailsResourceData{}.Attributes(),
+	}
+}
+
+func (d *TeamDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("slug"),
+		),
 	}
 }
 
@@ -183,50 +310,125 @@ func (d *TeamDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	teamID := ""
-
-	if !data.Name.IsNull() {
-		// Resolve the team by name
-		foundTeams, err := d.apiClient.FindTeams(api.FindTeamsRequest{Name: data.Name.ValueString()})
+	// Relationships are derived from the complete list of teams, which then
+	// also resolves names and slugs without further requests.
+	var hierarchy *api.TeamHierarchy
+	var teams []api.Team
+	if data.IncludeHierarchy.ValueBool() {
+		var err error
+		teams, err = d.apiClient.FindTeams(ctx, api.FindTeamsRequest{})
 		if err != nil {
-			resp.Diagnostics.AddError("Unexpected API error", fmt.Sprintf("Raw: %s\n", err.Error()))
+			addAPIError(&resp.Diagnostics, err)
 			return
 		}
+		hierarchy = api.NewTeamHierarchy(teams)
+	}
 
-		if len(foundTeams) == 0 {
-			resp.Diagnostics.AddError("Missing data source", fmt.Sprintf("Could not load data source for team with name %s", data.Name.ValueString()))
-			return
-		}
+	teamID := data.ID.ValueString()
 
-		if len(foundTeams) > 1 {
-			resp.Diagnostics.AddError("Multiple matches found where single result expected", fmt.Sprintf("Multiple results for team with name %s", data.Name.ValueString()))
+	if !data.Name.IsNull() || !data.Slug.IsNull() {
+		teamID = d.resolveTeamID(ctx, data.TeamResourceData, teams, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-
-		teamID = foundTeams[0].ID
 	}
 
-	if !data.ID.IsNull() && teamID == "" {
-		teamID = data.ID.ValueString()
+	if teamID == "" {
+		resp.Diagnostics.AddError("Missing required parameter for team loading - 'id', 'name' or 'slug'...", "")
+		return
 	}
 
-	if teamID == "" {
-		resp.Diagnostics.AddError("Missing required parameter for team loading - 'id' or 'name'...", "")
+	response, err := d.apiClient.FindTeamByID(ctx, teamID)
+	if api.IsNotFound(err) || (err == nil && response == nil) {
+		resp.Diagnostics.AddError("Missing data source", fmt.Sprintf("Could not load data source for team with ID %s", teamID))
 		return
 	}
 
-	response, err := d.apiClient.FindTeamByID(teamID)
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected API error", fmt.Sprintf("Raw: %s\n", err.Error()))
+		addAPIError(&resp.Diagnostics, err)
 		return
 	}
 
-	if response == nil {
-		resp.Diagnostics.AddError("Missing data source", fmt.Sprintf("Could not load data source for team with ID %s", data.ID.ValueString()))
+	config := data.TeamResourceData
+	includeHierarchy := data.IncludeHierarchy
+	data = newTeamDetailsResourceData(ctx, response, hierarchy)
+	data.IncludeHierarchy = includeHierarchy
+
+	checkTeamIdentifiers(config, data.TeamResourceData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data = newTeamDetailsResourceData(ctx, response)
-
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// resolveTeamID finds the id of the team matching the configured name or slug
+// among teams. When teams weren't listed, the complete list is only used if
+// cached, as it is shared by every lookup. Otherwise Span filters the teams.
+func (d *TeamDataSource) resolveTeamID(ctx context.Context, data TeamResourceData, teams []api.Team, diags *diag.Diagnostics) string {
+	attribute, value := "name", data.Name.ValueString()
+	if !data.Slug.IsNull() {
+		attribute, value = "slug", data.Slug.ValueString()
+	}
+
+	if teams == nil {
+		request := api.FindTeamsRequest{Name: data.Name.ValueString(), Slug: data.Slug.ValueString()}
+		if api.IsCaching(d.apiClient) {
+			request = api.FindTeamsRequest{}
+		}
+
+		var err error
+		teams, err = d.apiClient.FindTeams(ctx, request)
+		if err != nil {
+			addAPIError(diags, err)
+			return ""
+		}
+	}
+
+	var foundTeams []api.Team
+	for _, t := range teams {
+		if (attribute == "name" && t.Name == value) || (attribute == "slug" && t.Slug == value) {
+			foundTeams = append(foundTeams, t)
+		}
+	}
+
+	if len(foundTeams) == 0 {
+		diags.AddError("Missing data source", fmt.Sprintf("Could not load data source for team with %s %s", attribute, value))
+		return ""
+	}
+
+	if len(foundTeams) > 1 {
+		diags.AddError("Multiple matches found where single result expected", fmt.Sprintf("Multiple results for team with %s %s", attribute, value))
+		return ""
+	}
+
+	return foundTeams[0].ID
+}
+
+// checkTeamIdentifiers makes sure the resolved team matches every identifier
+// given in the configuration, as lookups may match loosely on the server.
+func checkTeamIdentifiers(config, resolved TeamResourceData, diags *diag.Diagnostics) {
+	identifiers := []struct {
+		attribute  string
+		configured types.String
+		resolved   types.String
+	}{
+		{"id", config.ID, resolved.ID},
+		{"name", config.Name, resolved.Name},
+		{"slug", config.Slug, resolved.Slug},
+	}
+
+	for _, identifier := range identifiers {
+		if identifier.configured.IsNull() || identifier.configured.IsUnknown() {
+			continue
+		}
+
+		if identifier.configured.ValueString() != identifier.resolved.ValueString() {
+			diags.AddAttributeError(
+				path.Root(identifier.attribute),
+				"Resolved team does not match configuration",
+				fmt.Sprintf("The team resolved by Span has %s %q, but %q was configured.", identifier.attribute, identifier.resolved.ValueString(), identifier.configured.ValueString()),
+			)
+		}
+	}
+}
@@ -46,7 +46,7 @@ func newTeamManifestDataSourceData(_ context.Context, in *api.TeamManifest) (*te
 		return nil, err
 	}
 
-	data.Vendors, err = dynamic.FromJSON(vendorsInput)
+	data.Vendors, err = dynamic.FromJSONWithMode(vendorsInput, dynamic.ModeCollections)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +74,8 @@ func (tmr teamManifestDataSourceData) Attributes() map[string]schema.Attribute {
 			Computed:            true,
 		},
 		"vendors": schema.DynamicAttribute{
-			Optional: true,
+			MarkdownDescription: "Vendor properties of the manifest. Arrays and objects whose elements share a type are exposed as lists and maps, so they can be used with `for_each`.",
+			Optional:            true,
 		},
 	}
 }
@@ -122,9 +123,9 @@ func (d *TeamManifestDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	response, err := d.apiClient.FindTeamManifestByTeamID(data.TeamID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Unexpected API error", fmt.Sprintf("Raw: %s\n", err.Error()))
+	response, err := d.apiClient.FindTeamManifestByTeamID(ctx, data.TeamID.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -137,6 +138,7 @@ func (d *TeamManifestDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	if err != nil {
 		resp.Diagnostics.AddError("Could not load manifest", fmt.Sprintf("Schema mapping for manifiest failed with %v", err))
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, resource)...)
@@ -0,0 +1,43 @@
+package span
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTeamManifestDataSource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team_manifest" "test" {
+  team_id = "t-platform"
+}
+
+output "schedule" {
+  value = data.span_team_manifest.test.vendors.pagerduty.schedule
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_team_manifest.test", "team_name", "Platform"),
+					resource.TestCheckResourceAttr("data.span_team_manifest.test", "reference", "platform"),
+					resource.TestCheckResourceAttr("data.span_team_manifest.test", "tech_lead", "ada@example.com"),
+					resource.TestCheckOutput("schedule", "PI7DH85"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team_manifest" "missing" {
+  team_id = "t-eng"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing data source"),
+			},
+		},
+	})
+}
@@ -0,0 +1,264 @@
+package span
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccTeamDataSource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "by_id" {
+  id                = "t-platform"
+  include_hierarchy = true
+}
+
+data "span_team" "by_name" {
+  name              = "Engineering"
+  include_hierarchy = true
+}
+
+data "span_team" "by_slug" {
+  slug = "platform"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_team.by_id", "name", "Platform"),
+					resource.TestCheckResourceAttr("data.span_team.by_id", "parent_id", "t-eng"),
+					resource.TestCheckResourceAttr("data.span_team.by_id", "created_at", "2024-01-02T03:04:05Z"),
+					resource.TestCheckResourceAttr("data.span_team.by_id", "member_count", "2"),
+					resource.TestCheckTypeSetElemAttr("data.span_team.by_id", "lead_emails.*", "ada@example.com"),
+					resource.TestCheckResourceAttr("data.span_team.by_id", "member_emails.#", "2"),
+					resource.TestCheckResourceAttr("data.span_team.by_id", "ancestors.#", "1"),
+					resource.TestCheckResourceAttr("data.span_team.by_id", "ancestors.0.id", "t-eng"),
+					resource.TestCheckResourceAttr("data.span_team.by_name", "id", "t-eng"),
+					resource.TestCheckNoResourceAttr("data.span_team.by_name", "parent_id"),
+					resource.TestCheckResourceAttr("data.span_team.by_name", "children.#", "1"),
+					resource.TestCheckResourceAttr("data.span_team.by_name", "children.0.id", "t-platform"),
+					resource.TestCheckResourceAttr("data.span_team.by_slug", "id", "t-platform"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "missing" {
+  id = "t-missing"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing data source"),
+			},
+		},
+	})
+}
+
+func TestAccTeamDataSource_identifiers(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "test" {}
+`,
+				ExpectError: regexp.MustCompile("Missing Attribute Configuration"),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "test" {
+  id   = "t-platform"
+  slug = "platform"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.span_team.test", "name", "Platform"),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "test" {
+  id   = "t-eng"
+  slug = "platform"
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Resolved team does not match configuration.*id "t-platform", but\s+"t-eng" was configured`),
+			},
+		},
+	})
+}
+
+func TestAccTeamDataSource_withoutHierarchy(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "by_id" {
+  id = "t-platform"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_team.by_id", "name", "Platform"),
+					resource.TestCheckNoResourceAttr("data.span_team.by_id", "children.#"),
+					resource.TestCheckNoResourceAttr("data.span_team.by_id", "ancestors.#"),
+					// Id lookups don't list teams.
+					testAccCheckCalls(srv, apitest.RouteFindTeams, 0),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "by_slug" {
+  slug = "platform"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_team.by_slug", "id", "t-platform"),
+					resource.TestCheckNoResourceAttr("data.span_team.by_slug", "children.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeamDataSource_cached(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv, `cache {}`) + `
+data "span_team" "by_name" {
+  name = "Engineering"
+}
+
+data "span_team" "by_slug" {
+  slug = "platform"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_team.by_name", "id", "t-eng"),
+					resource.TestCheckResourceAttr("data.span_team.by_slug", "id", "t-platform"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeamDataSource_transientFaults(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					srv.InjectFault(apitest.RouteFindTeam, apitest.RateLimited(0))
+					srv.InjectFault(apitest.RouteFindTeam, apitest.ServerError())
+				},
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "test" {
+  id = "t-platform"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_team.test", "name", "Platform"),
+					testAccCheckCalls(srv, apitest.RouteFindTeam, 3),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeamDataSource_persistentFault(t *testing.T) {
+	srv := newTestServer(t)
+	srv.InjectFault(apitest.RouteFindTeam, apitest.Fault{Status: 500})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team" "test" {
+  id = "t-platform"
+}
+`,
+				ExpectError: regexp.MustCompile("(?i)server error"),
+			},
+		},
+	})
+}
+
+func TestAccTeamsDataSource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_teams" "all" {}
+
+data "span_teams" "limited" {
+  limit = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_teams.all", "teams.#", "2"),
+					resource.TestCheckResourceAttr("data.span_teams.limited", "teams.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeamTreeDataSource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "span_team_tree" "engineering" {
+  root_id = "t-eng"
+}
+
+data "span_team_tree" "shallow" {
+  root_id   = "t-eng"
+  max_depth = 0
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.span_team_tree.engineering", "teams.#", "2"),
+					resource.TestCheckResourceAttr("data.span_team_tree.engineering", "teams.0.id", "t-eng"),
+					resource.TestCheckResourceAttr("data.span_team_tree.engineering", "teams.0.depth", "0"),
+					resource.TestCheckResourceAttr("data.span_team_tree.engineering", "teams.1.id", "t-platform"),
+					resource.TestCheckResourceAttr("data.span_team_tree.engineering", "teams.1.depth", "1"),
+					resource.TestCheckResourceAttr("data.span_team_tree.engineering", "teams.1.ancestor_ids.0", "t-eng"),
+					resource.TestCheckResourceAttr("data.span_team_tree.shallow", "teams.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckCalls checks that route was requested the given number of
+// times, faults included.
+func testAccCheckCalls(srv *apitest.Server, route string, want int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if got := srv.Calls(route); got != want {
+			return fmt.Errorf("%s: got %d calls, want %d", route, got, want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+package span
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &TeamTreeDataSource{}
+
+func NewTeamTreeDataSource() datasource.DataSource {
+	return &TeamTreeDataSource{}
+}
+
+// TeamTreeDataSource lists the hierarchy of teams under a root team.
+type TeamTreeDataSource struct {
+	apiClient api.SpanAPIClient
+}
+
+type TeamTreeResourceData struct {
+	RootID   types.String `tfsdk:"root_id"`
+	MaxDepth types.Int64  `tfsdk:"max_depth"`
+	Teams    types.List   `tfsdk:"teams"`
+}
+
+type TeamTreeNode struct {
+	TeamResourceData
+	Depth       types.Int64 `tfsdk:"depth"`
+	AncestorIDs types.List  `tfsdk:"ancestor_ids"`
+}
+
+func (tn TeamTreeNode) Attributes() map[string]schema.Attribute {
+	attributes := TeamResourceData{}.Attributes()
+	attributes["depth"] = schema.Int64Attribute{
+		MarkdownDescription: "Distance to the root team, 0 for the root itself.",
+		Computed:            true,
+	}
+	attributes["ancestor_ids"] = schema.ListAttribute{
+		MarkdownDescription: "IDs of the teams from the root team down to the parent of this team.",
+		ElementType:         types.StringType,
+		Computed:            true,
+	}
+	return attributes
+}
+
+func (tn TeamTreeNode) AttrTypes() map[string]attr.Type {
+	attrTypes := TeamResourceData{}.AttrTypes()
+	attrTypes["depth"] = types.Int64Type
+	attrTypes["ancestor_ids"] = types.ListType{ElemType: types.StringType}
+	return attrTypes
+}
+
+func (d *TeamTreeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_tree"
+}
+
+func (d *TeamTreeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The hierarchy of teams under a root team, e.g. to roll up ownership to a given level of the organization.",
+		Attributes: map[string]schema.Attribute{
+			"root_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the team at the root of the hierarchy.",
+				Required:            true,
+			},
+			"max_depth": schema.Int64Attribute{
+				MarkdownDescription: "Optional maximum depth to descend to, 0 returns the root team only. The whole hierarchy is returned when unset.",
+				Optional:            true,
+			},
+			"teams": schema.ListNestedAttribute{
+				MarkdownDescription: "The root team followed by its descendants, depth first with siblings ordered by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: TeamTreeNode{}.Attributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *TeamTreeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(api.SpanAPIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configuration Type",
+			fmt.Sprintf("Expected a SpanAPIClient but got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.apiClient = apiClient
+}
+
+func newTeamTreeNodes(ctx context.Context, in []api.TeamNode, diags *diag.Diagnostics) types.List {
+	nodes := make([]TeamTreeNode, len(in))
+	for i, incoming := range in {
+		nodes[i].TeamResourceData = newTeamResourceData(ctx, &incoming.Team)
+		nodes[i].Depth = types.Int64Value(int64(incoming.Depth))
+
+		ancestorIDs, d := types.ListValueFrom(ctx, types.StringType, incoming.AncestorIDs)
+		diags.Append(d...)
+		nodes[i].AncestorIDs = ancestorIDs
+	}
+
+	result, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: TeamTreeNode{}.AttrTypes()}, nodes)
+
+	diags.Append(d...)
+
+	return result
+}
+
+func (d *TeamTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TeamTreeResourceData
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxDepth := -1
+	if !data.MaxDepth.IsNull() {
+		if data.MaxDepth.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_depth"), "Invalid depth", "The max_depth must not be negative.")
+			return
+		}
+		maxDepth = int(data.MaxDepth.ValueInt64())
+	}
+
+	teams, err := d.apiClient.FindTeams(ctx, api.FindTeamsRequest{})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	hierarchy := api.NewTeamHierarchy(teams)
+
+	if _, ok := hierarchy.Team(data.RootID.ValueString()); !ok {
+		resp.Diagnostics.AddError("Missing data source", fmt.Sprintf("Could not load data source for team with ID %s", data.RootID.ValueString()))
+		return
+	}
+
+	data.Teams = newTeamTreeNodes(ctx, hierarchy.Subtree(data.RootID.ValueString(), maxDepth), &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
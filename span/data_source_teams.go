@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -23,7 +24,8 @@ type TeamsDataSource struct {
 }
 
 type TeamsResourceData struct {
-	Teams types.List `tfsdk:"teams"`
+	Limit types.Int64 `tfsdk:"limit"`
+	Teams types.List  `tfsdk:"teams"`
 }
 
 func (d *TeamsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -34,6 +36,10 @@ func (d *TeamsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "List of teams available in Span.",
 		Attributes: map[string]schema.Attribute{
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Optional maximum number of teams to return. All pages are loaded when unset.",
+				Optional:            true,
+			},
 			"teams": schema.ListNestedAttribute{
 				Computed:            true,
 				MarkdownDescription: "Complete list of people within Span.",
@@ -65,20 +71,7 @@ func (d *TeamsDataSource) Configure(_ context.Context, req datasource.ConfigureR
 func newTeamsResourceData(ctx context.Context, in []api.Team, diags *diag.Diagnostics) TeamsResourceData {
 	var data TeamsResourceData
 
-	if len(in) == 0 {
-		return data
-	}
-
-	teams := make([]TeamResourceData, len(in))
-	for i, incoming := range in {
-		teams[i] = newTeamResourceData(ctx, &incoming)
-	}
-
-	result, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: TeamResourceData{}.AttrTypes()}, teams)
-
-	diags.Append(d...)
-
-	data.Teams = result
+	data.Teams = newTeamList(ctx, in, diags)
 
 	return data
 }
@@ -93,13 +86,20 @@ func (d *TeamsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	response, err := d.apiClient.FindTeams(api.FindTeamsRequest{})
+	if !data.Limit.IsNull() && data.Limit.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("limit"), "Invalid limit", "The limit must be a positive number.")
+		return
+	}
+
+	response, err := d.apiClient.FindTeams(ctx, api.FindTeamsRequest{Limit: int(data.Limit.ValueInt64())})
 	if err != nil {
-		resp.Diagnostics.AddError("Unexpected API error", fmt.Sprintf("Raw: %s\n", err.Error()))
+		addAPIError(&resp.Diagnostics, err)
 		return
 	}
 
+	limit := data.Limit
 	data = newTeamsResourceData(ctx, response, &resp.Diagnostics)
+	data.Limit = limit
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,90 @@
+package span
+
+import (
+	"fmt"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// apiErrorSummaries maps API error codes to a human readable diagnostic
+// summary and a hint on how to resolve the issue.
+var apiErrorSummaries = map[api.ErrorCode][2]string{
+	api.ErrorCodeUnauthorized: {
+		"Span API authentication failed",
+		"The access token was rejected. Verify the provider access_token or SPAN_ACCESS_TOKEN value.",
+	},
+	api.ErrorCodeForbidden: {
+		"Span API permission denied",
+		"The access token is valid but lacks the permissions required for this operation.",
+	},
+	api.ErrorCodeNotFound: {
+		"Span resource not found",
+		"The requested resource does not exist within Span.",
+	},
+	api.ErrorCodeRateLimited: {
+		"Span API rate limit exceeded",
+		"Too many requests were sent to Span. Lower Terraform parallelism or retry later.",
+	},
+	api.ErrorCodeValidationFailed: {
+		"Span API rejected the request",
+		"The request did not pass validation within Span. Review the configured values.",
+	},
+	api.ErrorCodeConflict: {
+		"Span API conflict",
+		"The resource was modified concurrently or is in a conflicting state.",
+	},
+	api.ErrorCodeServerError: {
+		"Span API server error",
+		"Span failed to process the request. This is usually transient, retry later.",
+	},
+	api.ErrorCodeTransportError: {
+		"Span API unreachable",
+		"The request to Span could not be completed. Verify connectivity and the api_endpoint value.",
+	},
+	api.ErrorCodeReadOnly: {
+		"Span catalog is read-only",
+		"The provider serves the catalog from snapshot_file, which only supports data sources. Unset snapshot_file to manage resources.",
+	},
+}
+
+// addAPIError appends an error diagnostic describing err in human readable form.
+func addAPIError(diags *diag.Diagnostics, err error) {
+	summary, hint := "Unexpected API error", ""
+
+	if s, ok := apiErrorSummaries[api.ErrorCodeOf(err)]; ok {
+		summary, hint = s[0], s[1]
+	}
+
+	detail := err.Error()
+	if hint != "" {
+		detail = hint + "\n\n" + detail
+	}
+
+	diags.AddError(summary, detail)
+}
+
+// addManifestWriteError is addAPIError with a dedicated explanation for
+// writes rejected because the manifest changed since it was last read.
+func addManifestWriteError(diags *diag.Diagnostics, teamID string, err error) {
+	if !api.IsConflict(err) {
+		addAPIError(diags, err)
+		return
+	}
+
+	diags.AddError(
+		"Team manifest changed outside of Terraform",
+		fmt.Sprintf("The manifest of team with ID %s was modified since Terraform last read it, the write was rejected to avoid overwriting those changes. "+
+			"Run a new plan to review them before applying again.\n\n%s", teamID, err.Error()),
+	)
+}
+
+// addManifestExistsError explains that a manifest can't be created because
+// the team already has one, which Terraform would otherwise overwrite.
+func addManifestExistsError(diags *diag.Diagnostics, teamID string, err error) {
+	diags.AddError(
+		"Team manifest already exists",
+		fmt.Sprintf("The team with ID %s already has a manifest, creating it would overwrite it. "+
+			"Import the existing manifest to manage it with Terraform, e.g. `terraform import span_team_manifest.<name> %s`.\n\n%s", teamID, teamID, err.Error()),
+	)
+}
@@ -1,6 +1,8 @@
 package dynamic
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -9,6 +11,44 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// NumberPrecision is the mantissa precision used for non integer numbers,
+// matching the precision Terraform itself uses for number values.
+const NumberPrecision = 512
+
+// Unmarshal decodes json keeping numbers as json.Number, so large integers
+// and decimals are not rounded through float64.
+func Unmarshal(b []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("Unexpected trailing content after json value")
+	}
+
+	return v, nil
+}
+
+// ParseNumber converts a json number into a big.Float without loss.
+// Integers get exactly as much precision as they need, any other number
+// is parsed with NumberPrecision bits.
+func ParseNumber(n json.Number) (*big.Float, error) {
+	if i, ok := new(big.Int).SetString(n.String(), 10); ok {
+		return new(big.Float).SetInt(i), nil
+	}
+
+	f, _, err := big.ParseFloat(n.String(), 10, NumberPrecision, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("Unexpected error parsing number [%s] -> [%v]", n, err)
+	}
+
+	return f, nil
+}
+
 // This is an internal mapping of json to tf attributes.
 // Attempts to resolve objects in depth.
 func mapToJSON(b []byte) (attr.Type, attr.Value, error) {
@@ -54,16 +94,20 @@ func mapToJSON(b []byte) (attr.Type, attr.Value, error) {
 		return typ, val, nil
 	}
 
-	var v interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
+	v, err := Unmarshal(b)
+	if err != nil {
 		return nil, nil, fmt.Errorf("Unexpected error unmarshalling content: [%s] -> [%v]", string(b), err)
 	}
 
 	switch v := v.(type) {
 	case bool:
 		return types.BoolType, types.BoolValue(v), nil
-	case float64:
-		return types.NumberType, types.NumberValue(big.NewFloat(v)), nil
+	case json.Number:
+		f, err := ParseNumber(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.NumberType, types.NumberValue(f), nil
 	case string:
 		return types.StringType, types.StringValue(v), nil
 	case nil:
@@ -81,3 +125,147 @@ func FromJSON(b []byte) (types.Dynamic, error) {
 	}
 	return types.DynamicValue(v), nil
 }
+
+// This is an internal mapping of tf attributes to json compatible values.
+// Numbers are kept as json.Number to avoid float rounding.
+func mapFromValue(v attr.Value) (any, error) {
+	if v == nil || v.IsNull() {
+		return nil, nil
+	}
+
+	if v.IsUnknown() {
+		return nil, fmt.Errorf("Cannot serialize unknown value of type %s", v.Type(context.Background()))
+	}
+
+	switch v := v.(type) {
+	case types.Dynamic:
+		return mapFromValue(v.UnderlyingValue())
+	case types.Object:
+		return mapFromElements(v.Attributes())
+	case types.Map:
+		return mapFromElements(v.Elements())
+	case types.Tuple:
+		return mapFromSlice(v.Elements())
+	case types.List:
+		return mapFromSlice(v.Elements())
+	case types.Set:
+		return mapFromSlice(v.Elements())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Number:
+		if v.ValueBigFloat().IsInf() {
+			return nil, fmt.Errorf("Cannot serialize infinite number")
+		}
+		return numberToJSON(v.ValueBigFloat()), nil
+	default:
+		return nil, fmt.Errorf("Encountered unsupported value type: %T", v)
+	}
+}
+
+func mapFromElements(elements map[string]attr.Value) (map[string]any, error) {
+	out := make(map[string]any, len(elements))
+	for k, e := range elements {
+		v, err := mapFromValue(e)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+func mapFromSlice(elements []attr.Value) ([]any, error) {
+	out := make([]any, len(elements))
+	for i, e := range elements {
+		v, err := mapFromValue(e)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// numberToJSON formats f with the shortest decimal representation which
+// parses back to the same value. Integers held exactly by the mantissa are
+// written in full rather than in exponent form.
+func numberToJSON(f *big.Float) json.Number {
+	if f.IsInt() && f.MantExp(nil) <= int(f.Prec()) {
+		return json.Number(f.Text('f', 0))
+	}
+	return json.Number(f.Text('g', -1))
+}
+
+// Equal reports whether two json documents are semantically equal. Object
+// keys order and number formatting (e.g. 1 vs 1.0) are ignored.
+func Equal(a, b []byte) (bool, error) {
+	av, err := Unmarshal(a)
+	if err != nil {
+		return false, err
+	}
+
+	bv, err := Unmarshal(b)
+	if err != nil {
+		return false, err
+	}
+
+	return valuesEqual(av, bv), nil
+}
+
+func valuesEqual(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, av := range a {
+			bv, ok := b[k]
+			if !ok || !valuesEqual(av, bv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !valuesEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, aErr := ParseNumber(a)
+		bf, bErr := ParseNumber(b)
+		return aErr == nil && bErr == nil && af.Cmp(bf) == 0
+	default:
+		return a == b
+	}
+}
+
+// ToJSON serializes a TF dynamic value to json, the reverse of FromJSON.
+func ToJSON(v types.Dynamic) ([]byte, error) {
+	out, err := mapFromValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out)
+}
+
+// IsFullyKnown reports whether v and every value nested within it are known.
+func IsFullyKnown(v attr.Value) bool {
+	tfv, err := v.ToTerraformValue(context.Background())
+	if err != nil {
+		return false
+	}
+	return tfv.IsFullyKnown()
+}
@@ -0,0 +1,242 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		// typ is the type ModeCollections is expected to pick.
+		typ attr.Type
+	}{
+		{name: "null", in: `null`},
+		{name: "bool", in: `true`, typ: types.BoolType},
+		{name: "string", in: `"PI7DH85"`, typ: types.StringType},
+		{name: "number", in: `42`, typ: types.NumberType},
+		{
+			name: "object",
+			in:   `{"schedule":"PI7DH85","escalate":true,"levels":3}`,
+			typ: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"schedule": types.StringType,
+				"escalate": types.BoolType,
+				"levels":   types.NumberType,
+			}},
+		},
+		{
+			name: "map",
+			in:   `{"pagerduty":"PI7DH85","opsgenie":"OG1"}`,
+			typ:  types.MapType{ElemType: types.StringType},
+		},
+		{
+			name: "map with null",
+			in:   `{"pagerduty":"PI7DH85","opsgenie":null}`,
+			typ:  types.MapType{ElemType: types.StringType},
+		},
+		{
+			name: "tuple",
+			in:   `["PI7DH85",1,false]`,
+			typ:  types.TupleType{ElemTypes: []attr.Type{types.StringType, types.NumberType, types.BoolType}},
+		},
+		{
+			name: "list",
+			in:   `["a","b","a"]`,
+			typ:  types.ListType{ElemType: types.StringType},
+		},
+		{
+			name: "list of maps",
+			in:   `[{"id":"1","name":"a"},{"id":"2","name":null}]`,
+			typ:  types.ListType{ElemType: types.MapType{ElemType: types.StringType}},
+		},
+		{name: "empty object", in: `{}`, typ: types.ObjectType{AttrTypes: map[string]attr.Type{}}},
+		{name: "empty array", in: `[]`, typ: types.TupleType{ElemTypes: []attr.Type{}}},
+		{
+			name: "nested",
+			in:   `{"datadog":{"monitors":[{"id":1},{"id":2}],"tags":{"env":"prod"}},"slack":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := FromJSON([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("FromJSON: %v", err)
+			}
+			assertRoundTrip(t, v, tt.in)
+
+			v, err = FromJSONWithMode([]byte(tt.in), ModeCollections)
+			if err != nil {
+				t.Fatalf("FromJSONWithMode: %v", err)
+			}
+			assertRoundTrip(t, v, tt.in)
+
+			if tt.typ != nil {
+				if got := v.UnderlyingValue().Type(context.Background()); !got.Equal(tt.typ) {
+					t.Errorf("ModeCollections typed %s as %s, want %s", tt.in, got, tt.typ)
+				}
+			}
+		})
+	}
+}
+
+// Sets never come out of FromJSON, but users may pass them as vendors.
+func TestToJSONSet(t *testing.T) {
+	set := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("a"),
+		types.StringValue("b"),
+	})
+
+	out, err := ToJSON(types.DynamicValue(set))
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+
+	v, err := FromJSONWithMode(out, ModeCollections)
+	if err != nil {
+		t.Fatalf("FromJSONWithMode: %v", err)
+	}
+	assertRoundTrip(t, v, string(out))
+
+	if got, want := v.UnderlyingValue().Type(context.Background()), (types.ListType{ElemType: types.StringType}); !got.Equal(want) {
+		t.Errorf("got type %s, want %s", got, want)
+	}
+}
+
+func TestToJSONUnknown(t *testing.T) {
+	v := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"schedule": types.StringType},
+		map[string]attr.Value{"schedule": types.StringUnknown()},
+	))
+
+	if _, err := ToJSON(v); err == nil {
+		t.Error("expected an error serializing an unknown value")
+	}
+}
+
+func TestToJSONInfinity(t *testing.T) {
+	v := types.DynamicValue(types.NumberValue(new(big.Float).SetInf(false)))
+
+	if _, err := ToJSON(v); err == nil {
+		t.Error("expected an error serializing an infinite number")
+	}
+}
+
+func assertRoundTrip(t *testing.T, v types.Dynamic, want string) {
+	t.Helper()
+
+	out, err := ToJSON(v)
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+
+	equal, err := Equal(out, []byte(want))
+	if err != nil {
+		t.Fatalf("Equal: %v", err)
+	}
+	if !equal {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+var numberRoundTripCases = []string{
+	"0",
+	"-0",
+	"1",
+	"-42",
+	"0.1",
+	"1.5",
+	"-2.5e-10",
+	"9007199254740993",     // 2^53+1, not representable as float64
+	"18446744073709551617", // 2^64+1, overflows uint64
+	"1e400",                // overflows float64
+	"1E-400",
+	"3.141592653589793238462643383279502884197",
+}
+
+func TestNumberRoundTrip(t *testing.T) {
+	for _, n := range numberRoundTripCases {
+		t.Run(n, func(t *testing.T) {
+			assertNumberRoundTrip(t, n)
+		})
+	}
+}
+
+func TestNumberToJSONIntegers(t *testing.T) {
+	// Integers are written in full rather than in exponent form.
+	for _, n := range []string{"9007199254740993", "18446744073709551617", "-123456789012345678901234567890"} {
+		f, err := ParseNumber(json.Number(n))
+		if err != nil {
+			t.Fatalf("ParseNumber(%s): %v", n, err)
+		}
+		if got := numberToJSON(f); got.String() != n {
+			t.Errorf("got %s, want %s", got, n)
+		}
+	}
+}
+
+func FuzzNumberRoundTrip(f *testing.F) {
+	for _, n := range numberRoundTripCases {
+		f.Add(n)
+	}
+
+	f.Fuzz(func(t *testing.T, n string) {
+		var number json.Number
+		if err := json.Unmarshal([]byte(n), &number); err != nil || number.String() != n {
+			t.Skip("not a json number")
+		}
+
+		// Formatting takes time proportional to the exponent, far beyond
+		// anything found in a manifest.
+		if f, err := ParseNumber(number); err != nil || f.IsInf() || abs(f.MantExp(nil)) > 1<<16 {
+			t.Skip("out of range")
+		}
+
+		assertNumberRoundTrip(t, n)
+	})
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// assertNumberRoundTrip checks that n survives numberToJSON(ParseNumber(n))
+// and both FromJSON modes followed by ToJSON.
+func assertNumberRoundTrip(t *testing.T, n string) {
+	t.Helper()
+
+	f, err := ParseNumber(json.Number(n))
+	if err != nil {
+		t.Fatalf("ParseNumber(%s): %v", n, err)
+	}
+
+	out := numberToJSON(f)
+	equal, err := Equal([]byte(out), []byte(n))
+	if err != nil {
+		t.Fatalf("Equal(%s, %s): %v", out, n, err)
+	}
+	if !equal {
+		t.Errorf("numberToJSON: got %s, want %s", out, n)
+	}
+
+	v, err := FromJSON([]byte(n))
+	if err != nil {
+		t.Fatalf("FromJSON(%s): %v", n, err)
+	}
+	assertRoundTrip(t, v, n)
+
+	v, err = FromJSONWithMode([]byte(n), ModeCollections)
+	if err != nil {
+		t.Fatalf("FromJSONWithMode(%s): %v", n, err)
+	}
+	assertRoundTrip(t, v, n)
+}
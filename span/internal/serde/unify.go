@@ -0,0 +1,310 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Mode selects how json arrays and objects are typed.
+type Mode int
+
+const (
+	// ModeStructural types every array as a tuple and every object as an
+	// object, with one type per element. This is the FromJSON behavior.
+	ModeStructural Mode = iota
+	// ModeCollections types arrays as lists and objects as maps whenever
+	// their elements unify to a single type, so adding an element to a list
+	// or a key to a map does not change the type of the whole value.
+	// Empty arrays and objects stay empty tuples and objects, which is what
+	// the `[]` and `{}` literals evaluate to in HCL.
+	ModeCollections
+)
+
+// FromJSONWithMode maps serialized json to a TF dynamic value using mode.
+func FromJSONWithMode(b []byte, mode Mode) (types.Dynamic, error) {
+	if mode == ModeStructural {
+		return FromJSON(b)
+	}
+
+	v, err := Unmarshal(b)
+	if err != nil {
+		return types.Dynamic{}, fmt.Errorf("Unexpected error unmarshalling content: [%s] -> [%v]", string(b), err)
+	}
+
+	if v == nil {
+		return types.DynamicNull(), nil
+	}
+
+	typ := unifiedType(v)
+
+	val, err := valueOf(v, typ)
+	if err != nil {
+		return types.Dynamic{}, err
+	}
+
+	return types.DynamicValue(val), nil
+}
+
+// unifiedType infers the type of a decoded json value. Nulls are typed as
+// DynamicType, which unifies with any other type.
+func unifiedType(v any) attr.Type {
+	switch v := v.(type) {
+	case bool:
+		return types.BoolType
+	case json.Number:
+		return types.NumberType
+	case string:
+		return types.StringType
+	case []any:
+		elemTypes := make([]attr.Type, len(v))
+		for i, e := range v {
+			elemTypes[i] = unifiedType(e)
+		}
+
+		if elem, ok := unifyAll(elemTypes); ok && len(v) > 0 && isConcrete(elem) {
+			return types.ListType{ElemType: elem}
+		}
+
+		return types.TupleType{ElemTypes: elemTypes}
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		elemTypes := make([]attr.Type, 0, len(v))
+		for _, k := range sortedKeys(v) {
+			attrTypes[k] = unifiedType(v[k])
+			elemTypes = append(elemTypes, attrTypes[k])
+		}
+
+		if elem, ok := unifyAll(elemTypes); ok && len(v) > 0 && isConcrete(elem) {
+			return types.MapType{ElemType: elem}
+		}
+
+		return types.ObjectType{AttrTypes: attrTypes}
+	default:
+		return types.DynamicType
+	}
+}
+
+func unifyAll(ts []attr.Type) (attr.Type, bool) {
+	var result attr.Type = types.DynamicType
+
+	for _, t := range ts {
+		var ok bool
+		if result, ok = unify(result, t); !ok {
+			return nil, false
+		}
+	}
+
+	return result, true
+}
+
+// unify returns the single type both a and b convert to, if any:
+//   - DynamicType (null) unifies with anything, yielding the other type.
+//   - equal types unify to themselves.
+//   - lists unify with lists and maps with maps when their elements unify.
+//   - an empty tuple unifies with any list, an empty object with any map.
+//   - objects with the same attribute names unify attribute by attribute.
+//
+// Everything else, primitives of different kinds in particular, doesn't.
+func unify(a, b attr.Type) (attr.Type, bool) {
+	switch {
+	case a.Equal(types.DynamicType):
+		return b, true
+	case b.Equal(types.DynamicType):
+		return a, true
+	case a.Equal(b):
+		return a, true
+	}
+
+	switch a := a.(type) {
+	case types.ListType:
+		switch b := b.(type) {
+		case types.ListType:
+			elem, ok := unify(a.ElemType, b.ElemType)
+			return types.ListType{ElemType: elem}, ok
+		case types.TupleType:
+			return a, len(b.ElemTypes) == 0
+		}
+	case types.MapType:
+		switch b := b.(type) {
+		case types.MapType:
+			elem, ok := unify(a.ElemType, b.ElemType)
+			return types.MapType{ElemType: elem}, ok
+		case types.ObjectType:
+			return a, len(b.AttrTypes) == 0
+		}
+	case types.TupleType:
+		if b, ok := b.(types.ListType); ok && len(a.ElemTypes) == 0 {
+			return b, true
+		}
+	case types.ObjectType:
+		switch b := b.(type) {
+		case types.MapType:
+			return b, len(a.AttrTypes) == 0
+		case types.ObjectType:
+			if len(a.AttrTypes) != len(b.AttrTypes) {
+				return nil, false
+			}
+
+			attrTypes := make(map[string]attr.Type, len(a.AttrTypes))
+			for k, at := range a.AttrTypes {
+				bt, ok := b.AttrTypes[k]
+				if !ok {
+					return nil, false
+				}
+
+				if attrTypes[k], ok = unify(at, bt); !ok {
+					return nil, false
+				}
+			}
+
+			return types.ObjectType{AttrTypes: attrTypes}, true
+		}
+	}
+
+	return nil, false
+}
+
+// isConcrete reports whether t holds no DynamicType, which collections
+// can't carry as element type.
+func isConcrete(t attr.Type) bool {
+	switch t := t.(type) {
+	case types.ListType:
+		return isConcrete(t.ElemType)
+	case types.MapType:
+		return isConcrete(t.ElemType)
+	case types.TupleType:
+		for _, e := range t.ElemTypes {
+			if !isConcrete(e) {
+				return false
+			}
+		}
+		return true
+	case types.ObjectType:
+		for _, e := range t.AttrTypes {
+			if !isConcrete(e) {
+				return false
+			}
+		}
+		return true
+	default:
+		return !t.Equal(types.DynamicType)
+	}
+}
+
+// valueOf converts a decoded json value to a TF value of type t.
+func valueOf(v any, t attr.Type) (attr.Value, error) {
+	if v == nil {
+		return nullOf(t), nil
+	}
+
+	switch t := t.(type) {
+	case types.ListType:
+		elems, err := valuesOf(v.([]any), func(int) attr.Type { return t.ElemType })
+		if err != nil {
+			return nil, err
+		}
+		val, diags := types.ListValue(t.ElemType, elems)
+		return val, diagsError(diags.HasError(), "list", v)
+	case types.TupleType:
+		elems, err := valuesOf(v.([]any), func(i int) attr.Type { return t.ElemTypes[i] })
+		if err != nil {
+			return nil, err
+		}
+		val, diags := types.TupleValue(t.ElemTypes, elems)
+		return val, diagsError(diags.HasError(), "tuple", v)
+	case types.MapType:
+		elems := map[string]attr.Value{}
+		for k, e := range v.(map[string]any) {
+			ev, err := valueOf(e, t.ElemType)
+			if err != nil {
+				return nil, err
+			}
+			elems[k] = ev
+		}
+		val, diags := types.MapValue(t.ElemType, elems)
+		return val, diagsError(diags.HasError(), "map", v)
+	case types.ObjectType:
+		attrs := map[string]attr.Value{}
+		for k, e := range v.(map[string]any) {
+			av, err := valueOf(e, t.AttrTypes[k])
+			if err != nil {
+				return nil, err
+			}
+			attrs[k] = av
+		}
+		val, diags := types.ObjectValue(t.AttrTypes, attrs)
+		return val, diagsError(diags.HasError(), "object", v)
+	}
+
+	switch v := v.(type) {
+	case bool:
+		return types.BoolValue(v), nil
+	case string:
+		return types.StringValue(v), nil
+	case json.Number:
+		f, err := ParseNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(f), nil
+	default:
+		return nil, fmt.Errorf("Encountered unknown JSON type: %T", v)
+	}
+}
+
+func valuesOf(in []any, typeOf func(int) attr.Type) ([]attr.Value, error) {
+	out := make([]attr.Value, len(in))
+	for i, e := range in {
+		v, err := valueOf(e, typeOf(i))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// nullOf returns the null value of type t.
+func nullOf(t attr.Type) attr.Value {
+	switch t := t.(type) {
+	case types.ListType:
+		return types.ListNull(t.ElemType)
+	case types.MapType:
+		return types.MapNull(t.ElemType)
+	case types.TupleType:
+		return types.TupleNull(t.ElemTypes)
+	case types.ObjectType:
+		return types.ObjectNull(t.AttrTypes)
+	}
+
+	switch {
+	case t.Equal(types.StringType):
+		return types.StringNull()
+	case t.Equal(types.NumberType):
+		return types.NumberNull()
+	case t.Equal(types.BoolType):
+		return types.BoolNull()
+	default:
+		return types.DynamicNull()
+	}
+}
+
+func diagsError(failed bool, kind string, v any) error {
+	if !failed {
+		return nil
+	}
+	return fmt.Errorf("Unexpected error mapping %s from content: [%v]", kind, v)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
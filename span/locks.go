@@ -0,0 +1,28 @@
+package span
+
+import "sync"
+
+// teamLocks serializes read-modify-write cycles on the manifest of a team
+// across the resources of a single provider instance.
+type teamLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newTeamLocks() *teamLocks {
+	return &teamLocks{locks: map[string]*sync.Mutex{}}
+}
+
+// lock blocks until the lock of teamID is acquired and returns its release.
+func (l *teamLocks) lock(teamID string) func() {
+	l.mu.Lock()
+	m, ok := l.locks[teamID]
+	if !ok {
+		m = &sync.Mutex{}
+		l.locks[teamID] = m
+	}
+	l.mu.Unlock()
+
+	m.Lock()
+	return m.Unlock
+}
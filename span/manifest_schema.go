@@ -0,0 +1,94 @@
+package span
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+)
+
+// manifestSchemaURL is the location the manifest schema is registered under
+// within the compiler, it is never fetched.
+const manifestSchemaURL = "span://manifest/vendors.schema.json"
+
+// loadManifestSchema compiles the JSON Schema for manifest vendors. value is
+// either the schema itself or the path of a file holding it.
+func loadManifestSchema(value string) (*jsonschema.Schema, error) {
+	content := []byte(value)
+
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		b, err := os.ReadFile(value)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest schema: %w", err)
+		}
+		content = b
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("manifest schema is not valid JSON: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(manifestSchemaURL, doc); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile(manifestSchemaURL)
+}
+
+// manifestSchemaErrors validates vendors against sch and returns one message
+// per violation, prefixed with the JSON pointer of the offending value.
+func manifestSchemaErrors(sch *jsonschema.Schema, vendors map[string]any) ([]string, error) {
+	// The validator only understands values as produced by encoding/json,
+	// round trip to get rid of anything else.
+	b, err := json.Marshal(vendors)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(strings.NewReader(string(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	err = sch.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+
+	verr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+
+	var errs []string
+	seen := map[string]bool{}
+	for _, unit := range verr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+
+		// Groups and references only wrap the errors of nested keywords.
+		switch unit.Error.Kind.(type) {
+		case *kind.Group, *kind.Reference:
+			continue
+		}
+
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "(root)"
+		}
+
+		msg := fmt.Sprintf("%s: %s", location, unit.Error)
+		if !seen[msg] {
+			seen[msg] = true
+			errs = append(errs, msg)
+		}
+	}
+
+	return errs, nil
+}
@@ -0,0 +1,108 @@
+package span
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testManifestSchema = `{
+  "type": "object",
+  "properties": {
+    "pagerduty": {
+      "type": "object",
+      "properties": {
+        "schedule": {"type": "string", "pattern": "^P[A-Z0-9]{6}$"}
+      },
+      "required": ["schedule"]
+    },
+    "statuspage": {
+      "type": "object",
+      "properties": {
+        "components": {"type": "array", "items": {"type": "object", "properties": {"id": {"type": "string"}}}}
+      }
+    }
+  },
+  "additionalProperties": {"type": "object"}
+}`
+
+func TestManifestSchemaErrors(t *testing.T) {
+	sch, err := loadManifestSchema(testManifestSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		vendors map[string]any
+		want    []string
+	}{
+		{
+			name:    "valid",
+			vendors: map[string]any{"pagerduty": map[string]any{"schedule": "PI7DH85"}, "slack": map[string]any{}},
+		},
+		{
+			name:    "nested violation",
+			vendors: map[string]any{"pagerduty": map[string]any{"schedule": "nope"}},
+			want:    []string{"/pagerduty/schedule: 'nope' does not match pattern '^P[A-Z0-9]{6}$'"},
+		},
+		{
+			name: "array item",
+			vendors: map[string]any{"statuspage": map[string]any{
+				"components": []any{map[string]any{"id": "api"}, map[string]any{"id": 42}},
+			}},
+			want: []string{"/statuspage/components/1/id: got number, want string"},
+		},
+		{
+			name:    "missing property",
+			vendors: map[string]any{"pagerduty": map[string]any{}},
+			want:    []string{"/pagerduty: missing property 'schedule'"},
+		},
+		{
+			name:    "additional property",
+			vendors: map[string]any{"slack": "#core-team"},
+			want:    []string{"/slack: got string, want object"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manifestSchemaErrors(sch, tt.vendors)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadManifestSchema(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "vendors.schema.json")
+	if err := os.WriteFile(file, []byte(testManifestSchema), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "inline", value: testManifestSchema},
+		{name: "file", value: file},
+		{name: "missing file", value: filepath.Join(t.TempDir(), "missing.json"), wantErr: true},
+		{name: "invalid json", value: `{"type": `, wantErr: true},
+		{name: "invalid schema", value: `{"type": 12}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadManifestSchema(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,21 +4,34 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/attuned-corp/terraform-provider-span/internal/api"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
 type spanProvider struct {
 	version string
 }
 
+// providerData is handed to resources on Configure. It carries the API client
+// along with provider wide settings resources act upon.
+type providerData struct {
+	client api.SpanAPIClient
+	// manifestSchema validates manifest vendors at plan time, nil if unset.
+	manifestSchema *jsonschema.Schema
+	// locks serializes writes to the manifest of a team.
+	locks *teamLocks
+}
+
 var (
 	_ provider.Provider = &spanProvider{}
 )
@@ -53,45 +66,179 @@ func (p *spanProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Description: "Span API's base endpoint for client communication.",
 				Optional:    true,
 			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: fmt.Sprintf("Maximum sustained rate of Span API requests shared by all data sources and resources. Set to 0 to disable client side limiting. Defaults to %d, requests are limited even when it is not set.", api.DefaultRequestsPerSecond),
+				Optional:    true,
+			},
+			"burst": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of Span API requests sent in a single burst. Defaults to %d, also when requests_per_second is not set.", api.DefaultBurst),
+				Optional:    true,
+			},
+			"manifest_schema": schema.StringAttribute{
+				Description: "JSON Schema which team manifest vendors must conform to, either inline or as the path of a file holding it. Manifests are validated at plan time.",
+				Optional:    true,
+			},
+			"snapshot_file": schema.StringAttribute{
+				Description: "Path of a catalog snapshot, as written by `terraform-provider-span snapshot`, to serve data sources from instead of the Span API. No access token is needed and resources can't be modified. May also be set with SPAN_SNAPSHOT_FILE.",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				Description: "Retry policy for transient Span API failures. Only reads and team manifest deletes are retried, other writes never are.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Description: fmt.Sprintf("Maximum number of attempts per request, including the first one. Set to 1 to disable retries. Defaults to %d.", api.DefaultRetryMaxAttempts),
+						Optional:    true,
+					},
+					"min_backoff": schema.StringAttribute{
+						Description: fmt.Sprintf("Initial backoff between retries as a Go duration, e.g. `500ms`. Defaults to %s.", api.DefaultRetryMinBackoff),
+						Optional:    true,
+					},
+					"max_backoff": schema.StringAttribute{
+						Description: fmt.Sprintf("Upper bound for the backoff and any Retry-After delay as a Go duration. Defaults to %s.", api.DefaultRetryMaxBackoff),
+						Optional:    true,
+					},
+				},
+			},
+			"cache": schema.SingleNestedBlock{
+				Description: "In-memory cache of catalog reads shared by all data sources and resources of this provider instance. Concurrent identical reads share a single request and any write through the provider invalidates the cache. Caching is disabled unless this block is set.",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Description: "Whether to cache reads. Defaults to true.",
+						Optional:    true,
+					},
+					"ttl": schema.StringAttribute{
+						Description: fmt.Sprintf("How long reads are cached for as a Go duration, e.g. `1m`. Defaults to %s.", api.DefaultCacheTTL),
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
 
 // PluginProviderConfiguration describes the provider data model.
 type ProviderConfiguration struct {
-	AccessToken types.String `tfsdk:"access_token"`
-	APIEndpoint types.String `tfsdk:"api_endpoint"`
+	AccessToken       types.String        `tfsdk:"access_token"`
+	APIEndpoint       types.String        `tfsdk:"api_endpoint"`
+	RequestsPerSecond types.Float64       `tfsdk:"requests_per_second"`
+	Burst             types.Int64         `tfsdk:"burst"`
+	ManifestSchema    types.String        `tfsdk:"manifest_schema"`
+	SnapshotFile      types.String        `tfsdk:"snapshot_file"`
+	Retry             *RetryConfiguration `tfsdk:"retry"`
+	Cache             *CacheConfiguration `tfsdk:"cache"`
 }
 
-// Configure is a start of lifecycle hook which terraform uses to insert all values
-// at instantiation. We are going to initialize & inject our API client
-func (p *spanProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
-	var cfg ProviderConfiguration
+// rateLimitOptions maps the rate limiting attributes onto API client options.
+func (cfg ProviderConfiguration) rateLimitOptions(diags *diag.Diagnostics) []api.ClientOption {
+	if cfg.RequestsPerSecond.IsNull() && cfg.Burst.IsNull() {
+		return nil
+	}
 
-	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
-	if resp.Diagnostics.HasError() {
-		return
+	rps := float64(api.DefaultRequestsPerSecond)
+	if !cfg.RequestsPerSecond.IsNull() {
+		rps = cfg.RequestsPerSecond.ValueFloat64()
+		if rps < 0 {
+			diags.AddAttributeError(path.Root("requests_per_second"), "Invalid rate limit", "requests_per_second must not be negative.")
+		}
+	}
+
+	burst := api.DefaultBurst
+	if !cfg.Burst.IsNull() {
+		burst = int(cfg.Burst.ValueInt64())
+		if burst < 1 {
+			diags.AddAttributeError(path.Root("burst"), "Invalid rate limit", "burst must be at least 1.")
+		}
+	}
+
+	return []api.ClientOption{api.WithRateLimit(rps, burst)}
+}
+
+// RetryConfiguration describes the retry block of the provider.
+type RetryConfiguration struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff  types.String `tfsdk:"min_backoff"`
+	MaxBackoff  types.String `tfsdk:"max_backoff"`
+}
+
+// clientOptions maps the retry block onto API client options.
+func (rc *RetryConfiguration) clientOptions(diags *diag.Diagnostics) []api.ClientOption {
+	if rc == nil {
+		return nil
+	}
+
+	policy := api.DefaultRetryPolicy()
+
+	if !rc.MaxAttempts.IsNull() {
+		policy.MaxAttempts = int(rc.MaxAttempts.ValueInt64())
+		if policy.MaxAttempts < 1 {
+			diags.AddAttributeError(path.Root("retry").AtName("max_attempts"), "Invalid retry configuration", "max_attempts must be at least 1.")
+		}
 	}
 
+	policy.MinBackoff = parseDuration(rc.MinBackoff, policy.MinBackoff, path.Root("retry").AtName("min_backoff"), diags)
+	policy.MaxBackoff = parseDuration(rc.MaxBackoff, policy.MaxBackoff, path.Root("retry").AtName("max_backoff"), diags)
+
+	if policy.MaxBackoff < policy.MinBackoff {
+		diags.AddAttributeError(path.Root("retry").AtName("max_backoff"), "Invalid retry configuration", "max_backoff must not be lower than min_backoff.")
+	}
+
+	return []api.ClientOption{api.WithRetryPolicy(policy)}
+}
+
+// CacheConfiguration describes the cache block of the provider.
+type CacheConfiguration struct {
+	Enabled types.Bool   `tfsdk:"enabled"`
+	TTL     types.String `tfsdk:"ttl"`
+}
+
+// clientOptions maps the cache block onto API client options.
+func (cc *CacheConfiguration) clientOptions(diags *diag.Diagnostics) []api.ClientOption {
+	if cc == nil || (!cc.Enabled.IsNull() && !cc.Enabled.ValueBool()) {
+		return nil
+	}
+
+	ttl := parseDuration(cc.TTL, api.DefaultCacheTTL, path.Root("cache").AtName("ttl"), diags)
+
+	return []api.ClientOption{api.WithCache(ttl)}
+}
+
+func parseDuration(v types.String, fallback time.Duration, p path.Path, diags *diag.Diagnostics) time.Duration {
+	if v.IsNull() || v.ValueString() == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(v.ValueString())
+	if err != nil || d < 0 {
+		diags.AddAttributeError(p, "Invalid duration", fmt.Sprintf("Expected a positive Go duration such as `500ms` or `2s`, got %q.", v.ValueString()))
+		return fallback
+	}
+
+	return d
+}
+
+// newAPIClient creates the client talking to the Span API.
+func (cfg ProviderConfiguration) newAPIClient(diags *diag.Diagnostics) api.SpanAPIClient {
 	token := os.Getenv("SPAN_ACCESS_TOKEN")
 	if cfg.AccessToken.ValueString() != "" {
 		token = cfg.AccessToken.ValueString()
 	}
 
 	if token == "" {
-		resp.Diagnostics.AddAttributeError(
+		diags.AddAttributeError(
 			path.Root("access_token"),
 			"Missing Span Access token",
 			"The SPAN_ACCESS_TOKEN was not correctly initialized. It needs to be provided within a configuration block or via the environment.",
 		)
-		return
+		return nil
 	} else if len(token) != 64 {
-		resp.Diagnostics.AddAttributeError(
+		diags.AddAttributeError(
 			path.Root("access_token"),
 			"Incorrect Span Access token",
 			fmt.Sprintf("The SPAN_ACCESS_TOKEN needs to be exactly 64 characters. Incorrect length encountered [%d]", len(token)),
 		)
-		return
+		return nil
 	}
 
 	fnOpts := []api.ClientOption{api.WithToken(token)}
@@ -105,18 +252,79 @@ func (p *spanProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		fnOpts = append(fnOpts, api.WithEndpoint(endpoint))
 	}
 
+	fnOpts = append(fnOpts, cfg.rateLimitOptions(diags)...)
+	fnOpts = append(fnOpts, cfg.Retry.clientOptions(diags)...)
+	fnOpts = append(fnOpts, cfg.Cache.clientOptions(diags)...)
+	if diags.HasError() {
+		return nil
+	}
+
 	client, err := api.NewSpanAPIClient(fnOpts...)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Failed instantiating Span API client",
 			fmt.Sprintf("Unexpected error %s", err.Error()),
 		)
+		return nil
+	}
+
+	return client
+}
+
+// Configure is a start of lifecycle hook which terraform uses to insert all values
+// at instantiation. We are going to initialize & inject our API client
+func (p *spanProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var cfg ProviderConfiguration
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &cfg)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	snapshotFile := os.Getenv("SPAN_SNAPSHOT_FILE")
+	if cfg.SnapshotFile.ValueString() != "" {
+		snapshotFile = cfg.SnapshotFile.ValueString()
+	}
+
+	var client api.SpanAPIClient
+	if snapshotFile != "" {
+		snapshot, err := api.ReadSnapshotFile(snapshotFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("snapshot_file"),
+				"Invalid snapshot file",
+				fmt.Sprintf("The catalog snapshot could not be loaded: %s", err.Error()),
+			)
+			return
+		}
+
+		tflog.Info(ctx, "serving Span catalog from snapshot", map[string]any{"snapshot_file": snapshotFile})
+		client = api.NewSnapshotClient(snapshot)
+	} else {
+		client = cfg.newAPIClient(&resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data := &providerData{client: client, locks: newTeamLocks()}
+
+	if cfg.ManifestSchema.ValueString() != "" {
+		var err error
+		data.manifestSchema, err = loadManifestSchema(cfg.ManifestSchema.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("manifest_schema"),
+				"Invalid manifest schema",
+				fmt.Sprintf("The manifest schema could not be loaded: %s", err.Error()),
+			)
+			return
+		}
+	}
+
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = data
 	tflog.Info(ctx, "terraform-provider-span - version information", map[string]any{"version": p.version})
 }
 
@@ -125,8 +333,10 @@ func (p *spanProvider) DataSources(_ context.Context) []func() datasource.DataSo
 	return []func() datasource.DataSource{
 		NewPersonDataSource,
 		NewPeopleDataSource,
+		NewPeopleByEmailDataSource,
 		NewTeamDataSource,
 		NewTeamsDataSource,
+		NewTeamTreeDataSource,
 		NewTeamManifestDataSource,
 	}
 }
@@ -134,6 +344,10 @@ func (p *spanProvider) DataSources(_ context.Context) []func() datasource.DataSo
 // Resources returns a slice of functions to instantiate supported Resource implementations
 func (p *spanProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewTeamResource,
+		NewTeamMemberResource,
+		NewTeamMembersResource,
 		NewTeamManifestResource,
+		NewTeamManifestVendorResource,
 	}
 }
@@ -0,0 +1,80 @@
+package span
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// testAccToken is accepted by the fake Span API, the provider requires
+// tokens of 64 characters.
+var testAccToken = strings.Repeat("t", 64)
+
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"span": providerserver.NewProtocol6WithError(NewProviderFactory("test")()),
+}
+
+// newTestServer starts a fake Span API seeded with a small catalog:
+//
+//	Engineering (t-eng)
+//	└── Platform (t-platform)
+//
+// with Ada leading Platform and Grace a member of both teams.
+func newTestServer(t *testing.T, opts ...apitest.Option) *apitest.Server {
+	t.Helper()
+
+	srv := apitest.NewServer(append([]apitest.Option{apitest.WithToken(testAccToken)}, opts...)...)
+	t.Cleanup(srv.Close)
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ada := api.Person{ID: "p-ada", Email: "ada@example.com", Name: "Ada Lovelace", GitHubLogin: "ada", Title: "Staff Engineer"}
+	grace := api.Person{ID: "p-grace", Email: "grace@example.com", Name: "Grace Hopper", GitHubLogin: "grace", ManagerEmail: "ada@example.com"}
+
+	srv.AddTeam(api.TeamWithMembers{
+		Team:    api.Team{NamedEntity: api.NamedEntity{ID: "t-eng", Name: "Engineering"}, Slug: "engineering", CreatedAt: created},
+		Members: []api.TeamMember{{Person: grace}},
+	})
+	srv.AddTeam(api.TeamWithMembers{
+		Team:    api.Team{NamedEntity: api.NamedEntity{ID: "t-platform", Name: "Platform"}, Slug: "platform", ParentID: "t-eng", CreatedAt: created},
+		Members: []api.TeamMember{{Person: ada, TeamLead: true}, {Person: grace}},
+	})
+
+	srv.AddPerson(api.PersonWithTeam{Person: ada, Teams: []api.NamedEntity{{ID: "t-platform", Name: "Platform"}}})
+	srv.AddPerson(api.PersonWithTeam{Person: grace, Teams: []api.NamedEntity{{ID: "t-eng", Name: "Engineering"}, {ID: "t-platform", Name: "Platform"}}})
+
+	srv.SetManifest("t-platform", api.TeamManifest{
+		TeamName:      "Platform",
+		TeamReference: "platform",
+		TechLead:      "ada@example.com",
+		Vendors: map[string]any{
+			"pagerduty": map[string]any{"schedule": "PI7DH85"},
+		},
+	})
+
+	return srv
+}
+
+// testAccProviderConfig configures the provider against srv, along with any
+// extra blocks. Retries back off briefly so that injected faults don't slow
+// tests down.
+func testAccProviderConfig(srv *apitest.Server, blocks ...string) string {
+	return fmt.Sprintf(`
+provider "span" {
+  access_token = %q
+  api_endpoint = %q
+
+  retry {
+    max_attempts = 3
+    min_backoff  = "1ms"
+    max_backoff  = "10ms"
+  }
+%s
+}
+`, testAccToken, srv.Endpoint(), strings.Join(blocks, "\n"))
+}
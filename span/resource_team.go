@@ -0,0 +1,237 @@
+package span
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &TeamResource{}
+	_ resource.ResourceWithConfigure   = &TeamResource{}
+	_ resource.ResourceWithImportState = &TeamResource{}
+)
+
+func NewTeamResource() resource.Resource {
+	return &TeamResource{}
+}
+
+// TeamResource is the managed implementation for a team.
+type TeamResource struct {
+	apiClient api.SpanAPIClient
+}
+
+type teamResourceData struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Slug      types.String `tfsdk:"slug"`
+	ParentID  types.String `tfsdk:"parent_id"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+func (tr teamResourceData) Attributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Immutable ID for the Span team resource",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the team.",
+			Required:            true,
+		},
+		"slug": schema.StringAttribute{
+			MarkdownDescription: "URL friendly unique slug for the team. Derived from the name by Span when not set. Span doesn't allow changing the slug, a new value replaces the team.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+			},
+		},
+		"parent_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the parent team. Teams without a parent sit at the top level of the organization.",
+			Optional:            true,
+		},
+		"created_at": schema.StringAttribute{
+			MarkdownDescription: "Creation timestamp of the team in RFC3339 format.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+func (tr *teamResourceData) apply(in *api.Team) {
+	tr.ID = types.StringValue(in.ID)
+	tr.Name = types.StringValue(in.Name)
+	tr.Slug = types.StringValue(in.Slug)
+	tr.ParentID = types.StringNull()
+	tr.CreatedAt = types.StringNull()
+
+	if in.ParentID != "" {
+		tr.ParentID = types.StringValue(in.ParentID)
+	}
+
+	if !in.CreatedAt.IsZero() {
+		tr.CreatedAt = types.StringValue(in.CreatedAt.Format(time.RFC3339))
+	}
+}
+
+func (r *TeamResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team"
+}
+
+func (r *TeamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A managed resource for a team within Span. Import by team id or slug.",
+		Attributes:          teamResourceData{}.Attributes(),
+	}
+}
+
+func (r *TeamResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected provider data but got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.apiClient = data.client
+}
+
+func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data teamResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.apiClient.CreateTeam(ctx, api.CreateTeamRequest{
+		Name:     data.Name.ValueString(),
+		Slug:     data.Slug.ValueString(),
+		ParentID: data.ParentID.ValueString(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	data.apply(team)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data teamResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.apiClient.FindTeamByID(ctx, data.ID.ValueString())
+	if api.IsNotFound(err) || (err == nil && team == nil) {
+		tflog.Warn(ctx, "team not found, removing from state", map[string]any{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	data.apply(&team.Team)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data teamResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.apiClient.UpdateTeam(ctx, data.ID.ValueString(), api.UpdateTeamRequest{
+		Name:     data.Name.ValueString(),
+		ParentID: data.ParentID.ValueString(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	data.apply(team)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data teamResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.apiClient.DeleteTeam(ctx, data.ID.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+}
+
+// ImportState accepts either the id or the slug of the team.
+func (r *TeamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	_, err := r.apiClient.FindTeamByID(ctx, req.ID)
+	if err == nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+		return
+	}
+
+	if !api.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	teams, err := r.apiClient.FindTeams(ctx, api.FindTeamsRequest{Slug: req.ID})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	for _, team := range teams {
+		if team.Slug == req.ID {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), team.ID)...)
+			return
+		}
+	}
+
+	resp.Diagnostics.AddError("Cannot import team", fmt.Sprintf("No team with id or slug %q was found within Span.", req.ID))
+}
@@ -0,0 +1,601 @@
+package span
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	dynamic "github.com/attuned-corp/terraform-provider-span/span/internal/serde"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+var (
+	_ resource.Resource                = &TeamManifestResource{}
+	_ resource.ResourceWithConfigure   = &TeamManifestResource{}
+	_ resource.ResourceWithImportState = &TeamManifestResource{}
+
+	_ resource.ResourceWithConfigValidators = &TeamManifestResource{}
+	_ resource.ResourceWithValidateConfig   = &TeamManifestResource{}
+	_ resource.ResourceWithModifyPlan       = &TeamManifestResource{}
+)
+
+func NewTeamManifestResource() resource.Resource {
+	return &TeamManifestResource{}
+}
+
+// TeamManifestResource is the managed implementation for a team manifest.
+type TeamManifestResource struct {
+	apiClient      api.SpanAPIClient
+	manifestSchema *jsonschema.Schema
+	locks          *teamLocks
+}
+
+type teamManifestResourceData struct {
+	TeamID       types.String  `tfsdk:"team_id"`
+	TeamName     types.String  `tfsdk:"team_name"`
+	Reference    types.String  `tfsdk:"reference"`
+	TechLead     types.String  `tfsdk:"tech_lead"`
+	VendorsInput types.String  `tfsdk:"vendors_input"`
+	Vendors      types.Dynamic `tfsdk:"vendors"`
+	TypedVendors types.Object  `tfsdk:"typed_vendors"`
+	Version      types.String  `tfsdk:"version"`
+}
+
+func (tmr teamManifestResourceData) Attributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"team_id": schema.StringAttribute{
+			MarkdownDescription: "The team id owner for the manifest resource.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"reference": schema.StringAttribute{
+			MarkdownDescription: "Human formatted reference for the team.",
+			Required:            true,
+		},
+		"vendors_input": schema.StringAttribute{
+			MarkdownDescription: "JSON encoded object of vendor properties for the manifest, e.g. `jsonencode({pagerduty = {schedule = \"PI7DH85\"}})`. Conflicts with `vendors` and `typed_vendors`.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"team_name": schema.StringAttribute{
+			MarkdownDescription: "The name of the team owner for the manifest resource.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"tech_lead": schema.StringAttribute{
+			MarkdownDescription: "Email of the tech lead for said team.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"vendors": schema.DynamicAttribute{
+			MarkdownDescription: "Vendor properties of the manifest as a native object, e.g. `{ pagerduty = { schedule = \"PI7DH85\" } }`. Conflicts with `vendors_input` and `typed_vendors`.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"typed_vendors": typedVendorsAttribute(),
+		"version": schema.StringAttribute{
+			MarkdownDescription: "Revision of the manifest within Span. Writes are rejected if the manifest was modified since it was last read.",
+			Computed:            true,
+		},
+	}
+}
+
+// apply maps the API manifest onto the resource model. User supplied
+// vendors and vendors_input are kept as long as they are semantically equal
+// to what the API returned, otherwise the drift is surfaced.
+func (tmr *teamManifestResourceData) apply(in *api.TeamManifest) error {
+	tmr.TeamID = types.StringValue(in.TeamID)
+	tmr.TeamName = types.StringValue(in.TeamName)
+	tmr.Reference = types.StringValue(in.TeamReference)
+	tmr.TechLead = types.StringValue(in.TechLead)
+	tmr.Version = types.StringNull()
+
+	if in.Version != "" {
+		tmr.Version = types.StringValue(in.Version)
+	}
+
+	vendorsInput, err := json.Marshal(in.Vendors)
+	if err != nil {
+		return err
+	}
+
+	if equal, err := tmr.vendorsEqual(vendorsInput); err != nil || !equal {
+		tmr.Vendors, err = dynamic.FromJSONWithMode(vendorsInput, dynamic.ModeCollections)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tmr.applyTypedVendors(in.Vendors); err != nil {
+		return err
+	}
+
+	if tmr.VendorsInput.IsNull() || tmr.VendorsInput.IsUnknown() {
+		tmr.VendorsInput = types.StringValue(string(vendorsInput))
+		return nil
+	}
+
+	equal, err := dynamic.Equal([]byte(tmr.VendorsInput.ValueString()), vendorsInput)
+	if err != nil {
+		return err
+	}
+
+	if !equal {
+		tmr.VendorsInput = types.StringValue(string(vendorsInput))
+	}
+
+	return nil
+}
+
+// applyTypedVendors refreshes typed_vendors, when in use, unless it already
+// matches the API vendors.
+func (tmr *teamManifestResourceData) applyTypedVendors(vendors map[string]any) error {
+	if tmr.TypedVendors.IsNull() {
+		return nil
+	}
+
+	if current, err := vendorsFromTyped(tmr.TypedVendors); err == nil {
+		a, errA := json.Marshal(current)
+		b, errB := json.Marshal(vendors)
+		if errA == nil && errB == nil {
+			if equal, err := dynamic.Equal(a, b); err == nil && equal {
+				return nil
+			}
+		}
+	}
+
+	typed, err := typedFromVendors(vendors)
+	if err != nil {
+		return err
+	}
+
+	tmr.TypedVendors = typed
+
+	return nil
+}
+
+// drift lists what changed within in compared to the manifest last stored in
+// state. Nothing is reported without a prior state, e.g. after an import.
+func (tmr teamManifestResourceData) drift(in *api.TeamManifest) []string {
+	if tmr.VendorsInput.IsNull() || tmr.VendorsInput.IsUnknown() {
+		return nil
+	}
+
+	before, err := decodeVendors([]byte(tmr.VendorsInput.ValueString()))
+	if err != nil {
+		return nil
+	}
+
+	var changes []string
+	if !tmr.Reference.IsNull() && tmr.Reference.ValueString() != in.TeamReference {
+		changes = append(changes, "reference")
+	}
+
+	for _, name := range changedVendors(before, in.Vendors) {
+		changes = append(changes, "vendors."+name)
+	}
+
+	return changes
+}
+
+// changedVendors returns the sorted names of vendors which were added,
+// removed or modified between before and after.
+func changedVendors(before, after map[string]any) []string {
+	var changed []string
+
+	names := map[string]any{}
+	for name := range before {
+		names[name] = nil
+	}
+	for name := range after {
+		names[name] = nil
+	}
+
+	for _, name := range sortedKeys(names) {
+		a, errA := json.Marshal(before[name])
+		b, errB := json.Marshal(after[name])
+		if errA != nil || errB != nil {
+			continue
+		}
+
+		_, inBefore := before[name]
+		_, inAfter := after[name]
+		if equal, err := dynamic.Equal(a, b); err != nil || !equal || inBefore != inAfter {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
+
+// vendorsEqual reports whether the known vendors value matches b.
+func (tmr teamManifestResourceData) vendorsEqual(b []byte) (bool, error) {
+	if tmr.Vendors.IsNull() || tmr.Vendors.IsUnknown() || tmr.Vendors.IsUnderlyingValueUnknown() {
+		return false, nil
+	}
+
+	current, err := dynamic.ToJSON(tmr.Vendors)
+	if err != nil {
+		return false, err
+	}
+
+	return dynamic.Equal(current, b)
+}
+
+// request builds the API payload out of typed_vendors, the native vendors
+// value or the JSON encoded vendors_input, whichever is configured.
+func (tmr teamManifestResourceData) request(config teamManifestResourceData) (api.SetTeamManifestRequest, error) {
+	r := api.SetTeamManifestRequest{
+		Reference: tmr.Reference.ValueString(),
+		Vendors:   map[string]any{},
+	}
+
+	if !config.TypedVendors.IsNull() {
+		vendors, err := vendorsFromTyped(tmr.TypedVendors)
+		if err != nil {
+			return r, fmt.Errorf("typed_vendors could not be serialized: %w", err)
+		}
+
+		r.Vendors = vendors
+
+		return r, nil
+	}
+
+	if !config.Vendors.IsNull() {
+		b, err := dynamic.ToJSON(tmr.Vendors)
+		if err != nil {
+			return r, fmt.Errorf("vendors could not be serialized: %w", err)
+		}
+
+		if r.Vendors, err = decodeVendors(b); err != nil {
+			return r, fmt.Errorf("vendors must be an object: %w", err)
+		}
+
+		return r, nil
+	}
+
+	vendors, err := decodeVendors([]byte(tmr.VendorsInput.ValueString()))
+	if err != nil {
+		return r, fmt.Errorf("vendors_input must be a JSON encoded object: %w", err)
+	}
+
+	r.Vendors = vendors
+
+	return r, nil
+}
+
+// decodeVendors decodes a json object keeping numbers lossless.
+func decodeVendors(b []byte) (map[string]any, error) {
+	v, err := dynamic.Unmarshal(b)
+	if err != nil {
+		return nil, err
+	}
+
+	vendors, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a json object, got %T", v)
+	}
+
+	return vendors, nil
+}
+
+func (r *TeamManifestResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_manifest"
+}
+
+func (r *TeamManifestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A managed resource for manifest details stored for a team within Span.",
+		Attributes:          teamManifestResourceData{}.Attributes(),
+	}
+}
+
+func (r *TeamManifestResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("vendors"),
+			path.MatchRoot("vendors_input"),
+			path.MatchRoot("typed_vendors"),
+		),
+	}
+}
+
+// ValidateConfig checks the settings of well known vendors against the
+// vendor registry, whichever way vendors are configured. Values which are
+// not known yet are checked once they are.
+func (r *TeamManifestResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data teamManifestResourceData
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Vendors.IsNull() && dynamic.IsFullyKnown(data.Vendors) {
+		b, err := dynamic.ToJSON(data.Vendors)
+		if err == nil {
+			validateVendors(path.Root("vendors"), b, &resp.Diagnostics)
+		}
+	}
+
+	if !data.VendorsInput.IsNull() && !data.VendorsInput.IsUnknown() {
+		validateVendors(path.Root("vendors_input"), []byte(data.VendorsInput.ValueString()), &resp.Diagnostics)
+	}
+
+	if data.TypedVendors.IsNull() || data.TypedVendors.IsUnknown() {
+		return
+	}
+
+	custom, ok := data.TypedVendors.Attributes()[customVendors].(types.Dynamic)
+	if !ok || custom.IsNull() || !dynamic.IsFullyKnown(custom) {
+		return
+	}
+
+	b, err := dynamic.ToJSON(custom)
+	if err != nil {
+		return
+	}
+
+	vendors, err := decodeVendors(b)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("typed_vendors").AtName(customVendors), "Invalid vendors", "custom must be an object keyed by vendor name.")
+		return
+	}
+
+	for _, name := range sortedKeys(vendors) {
+		if _, ok := vendorSchemas[name]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("typed_vendors").AtName(customVendors),
+				"Invalid vendors",
+				fmt.Sprintf("`%s` has typed settings, configure it as typed_vendors.%s instead.", name, name),
+			)
+		}
+	}
+}
+
+// validateVendors reports settings of well known vendors within the json
+// encoded vendors b which don't match the vendor registry.
+func validateVendors(p path.Path, b []byte, diags *diag.Diagnostics) {
+	vendors, err := decodeVendors(b)
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid vendors", fmt.Sprintf("%s must be an object keyed by vendor name: %v", p, err))
+		return
+	}
+
+	for _, e := range vendorsErrors(vendors) {
+		diags.AddAttributeError(p, "Invalid vendor settings", e)
+	}
+}
+
+func (r *TeamManifestResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected provider data but got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.apiClient = data.client
+	r.manifestSchema = data.manifestSchema
+	r.locks = data.locks
+}
+
+// ModifyPlan validates the planned vendors against the provider
+// manifest_schema. It runs after the provider is configured, unlike
+// ValidateConfig, and is skipped while vendors are not known yet.
+func (r *TeamManifestResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.manifestSchema == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data, configured teamManifestResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configured)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attribute := "vendors_input"
+	switch {
+	case !configured.TypedVendors.IsNull():
+		attribute = "typed_vendors"
+	case !configured.Vendors.IsNull():
+		attribute = "vendors"
+	}
+
+	if !dynamic.IsFullyKnown(configured.TypedVendors) || !dynamic.IsFullyKnown(configured.Vendors) || configured.VendorsInput.IsUnknown() {
+		tflog.Debug(ctx, "vendors not known yet, skipping manifest schema validation", map[string]any{"team_id": data.TeamID.ValueString()})
+		return
+	}
+
+	request, err := data.request(configured)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid vendors", err.Error())
+		return
+	}
+
+	errs, err := manifestSchemaErrors(r.manifestSchema, request.Vendors)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "Could not validate vendors", err.Error())
+		return
+	}
+
+	for _, e := range errs {
+		resp.Diagnostics.AddAttributeError(path.Root(attribute), "Vendors do not match the manifest schema", e)
+	}
+}
+
+func (r *TeamManifestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data teamManifestResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, req.Config, &data, api.SetTeamManifestRequest{IfNoneMatch: "*"}, &resp.State, &resp.Diagnostics)
+}
+
+func (r *TeamManifestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data teamManifestResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.apiClient.FindTeamManifestByTeamID(ctx, data.TeamID.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	// The manifest (or its team) was removed outside of Terraform.
+	if response == nil {
+		tflog.Warn(ctx, "team manifest not found, removing from state", map[string]any{"team_id": data.TeamID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if changes := data.drift(response); len(changes) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Team manifest changed outside of Terraform",
+			fmt.Sprintf("The manifest of team with ID %s was modified outside of Terraform: %s. Review the plan, applying it reverts these changes.",
+				data.TeamID.ValueString(), strings.Join(changes, ", ")),
+		)
+	}
+
+	if err := data.apply(response); err != nil {
+		resp.Diagnostics.AddError("Could not load manifest", fmt.Sprintf("Schema mapping for manifest failed with %v", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamManifestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior teamManifestResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, req.Config, &data, api.SetTeamManifestRequest{IfMatch: prior.Version.ValueString()}, &resp.State, &resp.Diagnostics)
+}
+
+func (r *TeamManifestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data teamManifestResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.locks.lock(data.TeamID.ValueString())
+	defer unlock()
+
+	err := r.apiClient.DeleteTeamManifest(ctx, data.TeamID.ValueString(), data.Version.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		addManifestWriteError(&resp.Diagnostics, data.TeamID.ValueString(), err)
+		return
+	}
+}
+
+// set pushes the planned manifest to Span and stores the resulting state.
+// The If-Match and If-None-Match preconditions of the write are taken from
+// conditions: creates must not overwrite an existing manifest and updates
+// must not overwrite changes made since the manifest was last read.
+func (r *TeamManifestResource) set(ctx context.Context, config tfsdk.Config, data *teamManifestResourceData, conditions api.SetTeamManifestRequest, state *tfsdk.State, diags *diag.Diagnostics) {
+	var configured teamManifestResourceData
+
+	diags.Append(config.Get(ctx, &configured)...)
+	if diags.HasError() {
+		return
+	}
+
+	request, err := data.request(configured)
+	if err != nil {
+		diags.AddError("Invalid vendors", err.Error())
+		return
+	}
+
+	unlock := r.locks.lock(data.TeamID.ValueString())
+	defer unlock()
+
+	request.IfMatch = conditions.IfMatch
+	request.IfNoneMatch = conditions.IfNoneMatch
+
+	response, err := r.apiClient.SetTeamManifest(ctx, data.TeamID.ValueString(), request)
+	if err != nil && request.IfNoneMatch != "" && api.IsConflict(err) {
+		addManifestExistsError(diags, data.TeamID.ValueString(), err)
+		return
+	}
+	if err != nil {
+		addManifestWriteError(diags, data.TeamID.ValueString(), err)
+		return
+	}
+
+	if response == nil {
+		diags.AddError("Missing manifest", fmt.Sprintf("Span did not return a manifest for team with ID %s", data.TeamID.ValueString()))
+		return
+	}
+
+	// Known planned values must be kept as is, Terraform rejects applies that
+	// diverge from the plan. Drift is picked up on the next refresh instead.
+	planned := *data
+	if err := data.apply(response); err != nil {
+		diags.AddError("Could not load manifest", fmt.Sprintf("Schema mapping for manifest failed with %v", err))
+		return
+	}
+
+	if !planned.VendorsInput.IsUnknown() {
+		data.VendorsInput = planned.VendorsInput
+	}
+
+	if !planned.Vendors.IsUnknown() {
+		data.Vendors = planned.Vendors
+	}
+
+	if dynamic.IsFullyKnown(planned.TypedVendors) {
+		data.TypedVendors = planned.TypedVendors
+	}
+
+	diags.Append(state.Set(ctx, data)...)
+}
+
+func (r *TeamManifestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("team_id"), req, resp)
+}
@@ -0,0 +1,193 @@
+package span
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const testAccTeamManifestResourceConfig = `
+resource "span_team_manifest" "test" {
+  team_id   = "t-eng"
+  reference = "engineering"
+  vendors = {
+    pagerduty = { schedule = "P123456" }
+  }
+}
+`
+
+func TestAccTeamManifestResource(t *testing.T) {
+	srv := newTestServer(t)
+	config := testAccProviderConfig(srv) + testAccTeamManifestResourceConfig
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTeamManifestDestroyed(srv, "t-eng"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("span_team_manifest.test", "reference", "engineering"),
+					resource.TestCheckResourceAttr("span_team_manifest.test", "vendors.pagerduty.schedule", "P123456"),
+					resource.TestCheckResourceAttrSet("span_team_manifest.test", "version"),
+					testAccCheckTeamManifestSchedule(srv, "t-eng", "P123456"),
+				),
+			},
+			// Import
+			{
+				ResourceName:                         "span_team_manifest.test",
+				ImportState:                          true,
+				ImportStateId:                        "t-eng",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "team_id",
+				ImportStateVerifyIgnore:              []string{"vendors_input"},
+			},
+			// Drift is detected and reverted
+			{
+				PreConfig: func() {
+					m, _ := srv.Manifest("t-eng")
+					m.Vendors = map[string]any{
+						"pagerduty": map[string]any{"schedule": "PCHANGED"},
+					}
+					srv.SetManifest("t-eng", m)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  testAccCheckTeamManifestSchedule(srv, "t-eng", "P123456"),
+			},
+			// Removal outside of Terraform drops it from state
+			{
+				PreConfig: func() {
+					srv.DeleteManifest("t-eng")
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  testAccCheckTeamManifestSchedule(srv, "t-eng", "P123456"),
+			},
+		},
+	})
+}
+
+func TestAccTeamManifestResource_existing(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_manifest" "test" {
+  team_id   = "t-platform"
+  reference = "platform"
+  vendors = {
+    pagerduty = { schedule = "P123456" }
+  }
+}
+`,
+				ExpectError: regexp.MustCompile("(?s)Team manifest already exists.*terraform import"),
+			},
+		},
+	})
+
+	if err := testAccCheckTeamManifestSchedule(srv, "t-platform", "PI7DH85")(nil); err != nil {
+		t.Errorf("existing manifest was overwritten: %v", err)
+	}
+}
+
+func TestAccTeamManifestResource_faults(t *testing.T) {
+	srv := newTestServer(t)
+	config := testAccProviderConfig(srv) + testAccTeamManifestResourceConfig
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Writes aren't idempotent and thus not retried.
+			{
+				PreConfig: func() {
+					srv.InjectFault(apitest.RouteSetManifest, apitest.ServerError())
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile("Span API server error"),
+			},
+			// Reads are retried, honoring Retry-After.
+			{
+				PreConfig: func() {
+					srv.InjectFault(apitest.RouteFindManifest, apitest.RateLimited(0))
+				},
+				Config: config,
+				Check:  testAccCheckTeamManifestSchedule(srv, "t-eng", "P123456"),
+			},
+		},
+	})
+}
+
+func testAccCheckTeamManifestSchedule(srv *apitest.Server, teamID string, want string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m, ok := srv.Manifest(teamID)
+		if !ok {
+			return fmt.Errorf("team %s has no manifest", teamID)
+		}
+
+		pagerduty, _ := m.Vendors["pagerduty"].(map[string]any)
+		if got := pagerduty["schedule"]; got != want {
+			return fmt.Errorf("team %s: got schedule %v, want %s", teamID, got, want)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTeamManifestDestroyed(srv *apitest.Server, teamID string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if _, ok := srv.Manifest(teamID); ok {
+			return fmt.Errorf("team %s still has a manifest", teamID)
+		}
+		return nil
+	}
+}
+
+func TestAccTeamManifestResource_manifestSchema(t *testing.T) {
+	srv := newTestServer(t)
+	schemaConfig := func(schema string) string {
+		return testAccProviderConfig(srv, fmt.Sprintf("manifest_schema = %q", schema))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTeamManifestDestroyed(srv, "t-eng"),
+		Steps: []resource.TestStep{
+			// Invalid schemas are rejected when configuring the provider
+			{
+				Config:      schemaConfig(`{"type": 12}`) + testAccTeamManifestResourceConfig,
+				ExpectError: regexp.MustCompile("Invalid manifest schema"),
+			},
+			{
+				Config:      schemaConfig(`{"type": `) + testAccTeamManifestResourceConfig,
+				ExpectError: regexp.MustCompile("(?s)Invalid manifest schema.*not valid JSON"),
+			},
+			// Violations are reported at plan time
+			{
+				Config:      schemaConfig(testManifestSchema) + strings.Replace(testAccTeamManifestResourceConfig, "P123456", "nope", 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("(?s)Vendors do not match the manifest schema.*/pagerduty/schedule: 'nope' does not\\s+match pattern"),
+			},
+			{
+				Config: schemaConfig(testManifestSchema) + testAccTeamManifestResourceConfig,
+				Check:  testAccCheckTeamManifestSchedule(srv, "t-eng", "P123456"),
+			},
+		},
+	})
+}
@@ -0,0 +1,376 @@
+package span
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	dynamic "github.com/attuned-corp/terraform-provider-span/span/internal/serde"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+var (
+	_ resource.Resource                   = &TeamManifestVendorResource{}
+	_ resource.ResourceWithConfigure      = &TeamManifestVendorResource{}
+	_ resource.ResourceWithImportState    = &TeamManifestVendorResource{}
+	_ resource.ResourceWithValidateConfig = &TeamManifestVendorResource{}
+)
+
+func NewTeamManifestVendorResource() resource.Resource {
+	return &TeamManifestVendorResource{}
+}
+
+// TeamManifestVendorResource manages the settings of a single vendor within
+// a team manifest, leaving the other vendors untouched.
+type TeamManifestVendorResource struct {
+	apiClient      api.SpanAPIClient
+	locks          *teamLocks
+	manifestSchema *jsonschema.Schema
+}
+
+type teamManifestVendorResourceData struct {
+	TeamID   types.String  `tfsdk:"team_id"`
+	Vendor   types.String  `tfsdk:"vendor"`
+	Settings types.Dynamic `tfsdk:"settings"`
+}
+
+func (tmv teamManifestVendorResourceData) Attributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"team_id": schema.StringAttribute{
+			MarkdownDescription: "The team id owner for the manifest.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"vendor": schema.StringAttribute{
+			MarkdownDescription: "Name of the vendor within the manifest, e.g. `pagerduty`.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"settings": schema.DynamicAttribute{
+			MarkdownDescription: "Settings of the vendor, e.g. `{ schedule = \"PI7DH85\" }`.",
+			Required:            true,
+		},
+	}
+}
+
+// apply maps the vendor settings found within the manifest onto the resource
+// model, keeping the current settings if they are semantically equal.
+func (tmv *teamManifestVendorResourceData) apply(settings any) error {
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	if !tmv.Settings.IsNull() && dynamic.IsFullyKnown(tmv.Settings) {
+		current, err := dynamic.ToJSON(tmv.Settings)
+		if err != nil {
+			return err
+		}
+
+		if equal, err := dynamic.Equal(current, b); err == nil && equal {
+			return nil
+		}
+	}
+
+	tmv.Settings, err = dynamic.FromJSONWithMode(b, dynamic.ModeCollections)
+
+	return err
+}
+
+// settings decodes the configured settings keeping numbers lossless.
+func (tmv teamManifestVendorResourceData) settings() (any, error) {
+	b, err := dynamic.ToJSON(tmv.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamic.Unmarshal(b)
+}
+
+func (r *TeamManifestVendorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_manifest_vendor"
+}
+
+func (r *TeamManifestVendorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A managed resource for the settings of a single vendor within a team manifest. " +
+			"Other vendors of the manifest are left untouched, so that several configurations can each own a vendor of the same team. " +
+			"Do not combine with a `span_team_manifest` resource for the same team, which owns every vendor.",
+		Attributes: teamManifestVendorResourceData{}.Attributes(),
+	}
+}
+
+func (r *TeamManifestVendorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data teamManifestVendorResourceData
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Vendor.IsUnknown() || !dynamic.IsFullyKnown(data.Settings) {
+		return
+	}
+
+	settings, err := data.settings()
+	if err != nil {
+		return
+	}
+
+	for _, e := range vendorSettingsErrors(data.Vendor.ValueString(), settings) {
+		resp.Diagnostics.AddAttributeError(path.Root("settings"), "Invalid vendor settings", e)
+	}
+}
+
+func (r *TeamManifestVendorResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected provider data but got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.apiClient = data.client
+	r.locks = data.locks
+	r.manifestSchema = data.manifestSchema
+}
+
+func (r *TeamManifestVendorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data teamManifestVendorResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, &data, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamManifestVendorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data teamManifestVendorResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	manifest, err := r.apiClient.FindTeamManifestByTeamID(ctx, data.TeamID.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	var settings any
+	var ok bool
+	if manifest != nil {
+		settings, ok = manifest.Vendors[data.Vendor.ValueString()]
+	}
+
+	// The vendor (or the whole manifest) was removed outside of Terraform.
+	if !ok {
+		tflog.Warn(ctx, "team manifest vendor not found, removing from state", map[string]any{
+			"team_id": data.TeamID.ValueString(),
+			"vendor":  data.Vendor.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err := data.apply(settings); err != nil {
+		resp.Diagnostics.AddError("Could not load vendor settings", fmt.Sprintf("Schema mapping for vendor settings failed with %v", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamManifestVendorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data teamManifestVendorResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, &data, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamManifestVendorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data teamManifestVendorResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamID, vendor := data.TeamID.ValueString(), data.Vendor.ValueString()
+
+	r.modify(ctx, teamID, &resp.Diagnostics, func(manifest *api.TeamManifest) bool {
+		if manifest == nil {
+			return false
+		}
+
+		if _, ok := manifest.Vendors[vendor]; !ok {
+			return false
+		}
+
+		delete(manifest.Vendors, vendor)
+		return true
+	})
+}
+
+// set writes the planned vendor settings into the manifest of the team.
+// Creating a vendor which already exists is refused, it must be imported.
+func (r *TeamManifestVendorResource) set(ctx context.Context, data *teamManifestVendorResourceData, create bool, diags *diag.Diagnostics) {
+	teamID, vendor := data.TeamID.ValueString(), data.Vendor.ValueString()
+
+	settings, err := data.settings()
+	if err != nil {
+		diags.AddAttributeError(path.Root("settings"), "Invalid vendor settings", fmt.Sprintf("settings could not be serialized: %v", err))
+		return
+	}
+
+	r.modify(ctx, teamID, diags, func(manifest *api.TeamManifest) bool {
+		if manifest == nil {
+			diags.AddError(
+				"Missing manifest",
+				fmt.Sprintf("Team with ID %s has no manifest yet. Vendors can only be added to an existing manifest.", teamID),
+			)
+			return false
+		}
+
+		if _, ok := manifest.Vendors[vendor]; ok && create {
+			diags.AddError(
+				"Vendor already exists",
+				fmt.Sprintf("The manifest of team with ID %s already holds settings for %s. Import it with the ID %s/%s to manage it.", teamID, vendor, teamID, vendor),
+			)
+			return false
+		}
+
+		manifest.Vendors[vendor] = settings
+		return true
+	})
+}
+
+// maxManifestWriteAttempts bounds how often a read-modify-write cycle is
+// started over when the manifest is modified concurrently.
+const maxManifestWriteAttempts = 3
+
+// modify runs a read-modify-write cycle on the manifest of a team while
+// holding the team lock. fn receives the current manifest, nil if there is
+// none, and reports whether the manifest must be written back.
+//
+// The write is conditional on the version read, so that changes made by
+// other writers in between are never overwritten. As only a single vendor
+// is modified, the cycle is simply started over in that case. The merged
+// vendors must match the provider manifest schema, if any.
+func (r *TeamManifestVendorResource) modify(ctx context.Context, teamID string, diags *diag.Diagnostics, fn func(*api.TeamManifest) bool) {
+	unlock := r.locks.lock(teamID)
+	defer unlock()
+
+	for attempt := 1; ; attempt++ {
+		manifest, err := r.apiClient.FindTeamManifestByTeamID(ctx, teamID)
+		if err != nil && !api.IsNotFound(err) {
+			addAPIError(diags, err)
+			return
+		}
+
+		if manifest != nil && manifest.Vendors == nil {
+			manifest.Vendors = map[string]any{}
+		}
+
+		if !fn(manifest) {
+			return
+		}
+
+		if !r.checkManifestSchema(teamID, manifest.Vendors, diags) {
+			return
+		}
+
+		_, err = r.apiClient.SetTeamManifest(ctx, teamID, api.SetTeamManifestRequest{
+			Reference: manifest.TeamReference,
+			Vendors:   manifest.Vendors,
+			IfMatch:   manifest.Version,
+		})
+		if err == nil {
+			return
+		}
+
+		if !api.IsConflict(err) || manifest.Version == "" || attempt == maxManifestWriteAttempts {
+			addManifestWriteError(diags, teamID, err)
+			return
+		}
+
+		tflog.Debug(ctx, "team manifest modified concurrently, retrying", map[string]any{"team_id": teamID, "attempt": attempt})
+	}
+}
+
+// checkManifestSchema validates the vendors about to be written for the team
+// against the manifest schema and reports whether they may be written.
+func (r *TeamManifestVendorResource) checkManifestSchema(teamID string, vendors map[string]any, diags *diag.Diagnostics) bool {
+	if r.manifestSchema == nil {
+		return true
+	}
+
+	errs, err := manifestSchemaErrors(r.manifestSchema, vendors)
+	if err != nil {
+		diags.AddAttributeError(path.Root("settings"), "Could not validate vendors", err.Error())
+		return false
+	}
+
+	for _, e := range errs {
+		diags.AddAttributeError(
+			path.Root("settings"),
+			"Vendors do not match the manifest schema",
+			fmt.Sprintf("The manifest of team with ID %s would not match the manifest schema once written: %s", teamID, e),
+		)
+	}
+
+	return len(errs) == 0
+}
+
+// ImportState accepts IDs in the form <team_id>/<vendor>.
+func (r *TeamManifestVendorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	teamID, vendor, ok := strings.Cut(req.ID, "/")
+	if !ok || teamID == "" || vendor == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an ID in the form <team_id>/<vendor>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), teamID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vendor"), vendor)...)
+}
@@ -0,0 +1,220 @@
+package span
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccTeamManifestVendorResourceConfig(channel string) string {
+	return fmt.Sprintf(`
+resource "span_team_manifest_vendor" "slack" {
+  team_id  = "t-platform"
+  vendor   = "slack"
+  settings = { channel = %q }
+}
+
+resource "span_team_manifest_vendor" "datadog" {
+  team_id  = "t-platform"
+  vendor   = "datadog"
+  settings = { team_handle = "@platform" }
+}
+
+resource "span_team_manifest_vendor" "statuspage" {
+  team_id  = "t-platform"
+  vendor   = "statuspage"
+  settings = { page = "kctbh9vrtdwd", components = [1, 2] }
+}
+`, channel)
+}
+
+func TestAccTeamManifestVendorResource(t *testing.T) {
+	srv := newTestServer(t)
+	slackSchema := `{"properties": {"slack": {"properties": {"channel": {"type": "string", "pattern": "^#"}}}}}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckTeamManifestVendor(srv, "t-platform", "slack", "channel", ""),
+			testAccCheckTeamManifestVendor(srv, "t-platform", "datadog", "team_handle", ""),
+			testAccCheckTeamManifestVendor(srv, "t-platform", "statuspage", "page", ""),
+			testAccCheckTeamManifestSchedule(srv, "t-platform", "PI7DH85"),
+		),
+		Steps: []resource.TestStep{
+			// Vendors of the same team are written one after the other, so
+			// that none of the writes conflicts.
+			{
+				Config: testAccProviderConfig(srv) + testAccTeamManifestVendorResourceConfig("#platform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("span_team_manifest_vendor.slack", "settings.channel", "#platform"),
+					resource.TestCheckResourceAttr("span_team_manifest_vendor.statuspage", "settings.components.#", "2"),
+					testAccCheckTeamManifestVendor(srv, "t-platform", "slack", "channel", "#platform"),
+					testAccCheckTeamManifestVendor(srv, "t-platform", "datadog", "team_handle", "@platform"),
+					testAccCheckTeamManifestVendor(srv, "t-platform", "statuspage", "page", "kctbh9vrtdwd"),
+					testAccCheckTeamManifestSchedule(srv, "t-platform", "PI7DH85"),
+					testAccCheckCalls(srv, apitest.RouteSetManifest, 3),
+				),
+			},
+			// Import
+			{
+				ResourceName:                         "span_team_manifest_vendor.slack",
+				ImportState:                          true,
+				ImportStateId:                        "t-platform/slack",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "vendor",
+			},
+			{
+				ResourceName:  "span_team_manifest_vendor.slack",
+				ImportState:   true,
+				ImportStateId: "t-platform",
+				ExpectError:   regexp.MustCompile("Invalid import ID"),
+			},
+			// Update
+			{
+				Config: testAccProviderConfig(srv) + testAccTeamManifestVendorResourceConfig("#platform-team"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTeamManifestVendor(srv, "t-platform", "slack", "channel", "#platform-team"),
+					testAccCheckTeamManifestVendor(srv, "t-platform", "datadog", "team_handle", "@platform"),
+					testAccCheckCalls(srv, apitest.RouteSetManifest, 4),
+				),
+			},
+			// The merged manifest must match the manifest schema
+			{
+				Config:      testAccProviderConfig(srv, fmt.Sprintf("manifest_schema = %q", slackSchema)) + testAccTeamManifestVendorResourceConfig("platform"),
+				ExpectError: regexp.MustCompile("(?s)Vendors do not match the manifest schema.*/slack/channel"),
+			},
+			{
+				Config: testAccProviderConfig(srv, fmt.Sprintf("manifest_schema = %q", slackSchema)) + testAccTeamManifestVendorResourceConfig("#platform-team"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTeamManifestVendor(srv, "t-platform", "slack", "channel", "#platform-team"),
+					testAccCheckCalls(srv, apitest.RouteSetManifest, 4),
+				),
+			},
+		},
+	})
+}
+
+const testAccTeamManifestVendorResourceSlackConfig = `
+resource "span_team_manifest_vendor" "test" {
+  team_id  = "t-platform"
+  vendor   = "slack"
+  settings = { channel = "#platform" }
+}
+`
+
+func TestAccTeamManifestVendorResource_conflict(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Conflicting writes start over from a fresh read
+			{
+				PreConfig: func() {
+					srv.InjectFault(apitest.RouteSetManifest, apitest.Fault{Status: http.StatusPreconditionFailed, Times: 2})
+				},
+				Config: testAccProviderConfig(srv) + testAccTeamManifestVendorResourceSlackConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTeamManifestVendor(srv, "t-platform", "slack", "channel", "#platform"),
+					testAccCheckCalls(srv, apitest.RouteSetManifest, 3),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeamManifestVendorResource_persistentConflict(t *testing.T) {
+	srv := newTestServer(t)
+	srv.InjectFault(apitest.RouteSetManifest, apitest.Fault{Status: http.StatusPreconditionFailed})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig(srv) + testAccTeamManifestVendorResourceSlackConfig,
+				ExpectError: regexp.MustCompile("Team manifest changed outside of Terraform"),
+			},
+		},
+	})
+
+	if got := srv.Calls(apitest.RouteSetManifest); got != maxManifestWriteAttempts {
+		t.Errorf("got %d writes, want %d", got, maxManifestWriteAttempts)
+	}
+}
+
+func TestAccTeamManifestVendorResource_existing(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_manifest_vendor" "test" {
+  team_id  = "t-platform"
+  vendor   = "pagerduty"
+  settings = { schedule = "P123456" }
+}
+`,
+				ExpectError: regexp.MustCompile("(?s)Vendor already exists.*t-platform/pagerduty"),
+			},
+		},
+	})
+
+	if err := testAccCheckTeamManifestSchedule(srv, "t-platform", "PI7DH85")(nil); err != nil {
+		t.Errorf("existing vendor was overwritten: %v", err)
+	}
+	if got := srv.Calls(apitest.RouteSetManifest); got != 0 {
+		t.Errorf("got %d writes, want none", got)
+	}
+}
+
+func TestAccTeamManifestVendorResource_missingManifest(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_manifest_vendor" "test" {
+  team_id  = "t-eng"
+  vendor   = "slack"
+  settings = { channel = "#engineering" }
+}
+`,
+				ExpectError: regexp.MustCompile("(?s)Missing manifest.*t-eng has no manifest"),
+			},
+		},
+	})
+
+	if _, ok := srv.Manifest("t-eng"); ok {
+		t.Error("a manifest was created")
+	}
+}
+
+// testAccCheckTeamManifestVendor checks a setting of a vendor within the
+// manifest of a team, an empty want meaning that the vendor is absent.
+func testAccCheckTeamManifestVendor(srv *apitest.Server, teamID, vendor, setting, want string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m, _ := srv.Manifest(teamID)
+
+		settings, ok := m.Vendors[vendor].(map[string]any)
+		if want == "" {
+			if ok {
+				return fmt.Errorf("team %s: vendor %s still present", teamID, vendor)
+			}
+			return nil
+		}
+
+		if got := settings[setting]; got != want {
+			return fmt.Errorf("team %s: got %s.%s %v, want %s", teamID, vendor, setting, got, want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,232 @@
+package span
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &TeamMemberResource{}
+	_ resource.ResourceWithConfigure   = &TeamMemberResource{}
+	_ resource.ResourceWithImportState = &TeamMemberResource{}
+)
+
+func NewTeamMemberResource() resource.Resource {
+	return &TeamMemberResource{}
+}
+
+// TeamMemberResource manages a single membership of a team, leaving the
+// other members of the team untouched.
+type TeamMemberResource struct {
+	apiClient api.SpanAPIClient
+}
+
+type teamMemberResourceData struct {
+	TeamID   types.String `tfsdk:"team_id"`
+	Email    types.String `tfsdk:"email"`
+	TeamLead types.Bool   `tfsdk:"team_lead"`
+	Name     types.String `tfsdk:"name"`
+}
+
+func (tm teamMemberResourceData) Attributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"team_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the team.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"email": schema.StringAttribute{
+			MarkdownDescription: "Email of the person to add to the team.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"team_lead": schema.BoolAttribute{
+			MarkdownDescription: "Whether the person leads the team. Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the person.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+// apply maps the API member onto the resource model, keeping the configured
+// email casing as emails are matched case insensitively.
+func (tm *teamMemberResourceData) apply(in *api.TeamMember) {
+	if !strings.EqualFold(tm.Email.ValueString(), in.Email) {
+		tm.Email = types.StringValue(in.Email)
+	}
+	tm.TeamLead = types.BoolValue(in.TeamLead)
+	tm.Name = types.StringValue(in.Name)
+}
+
+// findMember returns the member of team with the given email, if any.
+func findMember(team *api.TeamWithMembers, email string) *api.TeamMember {
+	for i, m := range team.Members {
+		if strings.EqualFold(m.Email, email) {
+			return &team.Members[i]
+		}
+	}
+	return nil
+}
+
+func (r *TeamMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_member"
+}
+
+func (r *TeamMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A managed resource for the membership of a single person within a team. " +
+			"Other members of the team are left untouched. Do not combine with a `span_team_members` resource for the same team. " +
+			"Import with `<team_id>/<email>`.",
+		Attributes: teamMemberResourceData{}.Attributes(),
+	}
+}
+
+func (r *TeamMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected provider data but got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.apiClient = data.client
+}
+
+func (r *TeamMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data teamMemberResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := r.apiClient.SetTeamMember(ctx, data.TeamID.ValueString(), data.Email.ValueString(), api.SetTeamMemberRequest{
+		TeamLead: data.TeamLead.ValueBool(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	data.apply(member)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data teamMemberResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.apiClient.FindTeamByID(ctx, data.TeamID.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	var member *api.TeamMember
+	if team != nil {
+		member = findMember(team, data.Email.ValueString())
+	}
+
+	// The person (or the whole team) was removed outside of Terraform.
+	if member == nil {
+		tflog.Warn(ctx, "team member not found, removing from state", map[string]any{
+			"team_id": data.TeamID.ValueString(),
+			"email":   data.Email.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.apply(member)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data teamMemberResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := r.apiClient.SetTeamMember(ctx, data.TeamID.ValueString(), data.Email.ValueString(), api.SetTeamMemberRequest{
+		TeamLead: data.TeamLead.ValueBool(),
+	})
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	data.apply(member)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data teamMemberResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.apiClient.RemoveTeamMember(ctx, data.TeamID.ValueString(), data.Email.ValueString())
+	if err != nil && !api.IsNotFound(err) {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+}
+
+// ImportState accepts IDs in the form <team_id>/<email>.
+func (r *TeamMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	teamID, email, ok := strings.Cut(req.ID, "/")
+	if !ok || teamID == "" || email == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an ID in the form <team_id>/<email>, got %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), teamID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("email"), email)...)
+}
@@ -0,0 +1,74 @@
+package span
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTeamMemberResource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTeamMembers(srv, "t-eng", map[string]bool{"grace@example.com": false}),
+		Steps: []resource.TestStep{
+			// Other members are left untouched
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_member" "test" {
+  team_id = "t-eng"
+  email   = "Ada@Example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("span_team_member.test", "email", "Ada@Example.com"),
+					resource.TestCheckResourceAttr("span_team_member.test", "name", "Ada Lovelace"),
+					resource.TestCheckResourceAttr("span_team_member.test", "team_lead", "false"),
+					testAccCheckTeamMembers(srv, "t-eng", map[string]bool{"ada@example.com": false, "grace@example.com": false}),
+				),
+			},
+			// The email casing returned by Span doesn't cause a diff
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_member" "test" {
+  team_id = "t-eng"
+  email   = "Ada@Example.com"
+}
+`,
+				PlanOnly: true,
+			},
+			// Import
+			{
+				ResourceName:                         "span_team_member.test",
+				ImportState:                          true,
+				ImportStateId:                        "t-eng/Ada@Example.com",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "email",
+			},
+			{
+				ResourceName:  "span_team_member.test",
+				ImportState:   true,
+				ImportStateId: "ada@example.com",
+				ExpectError:   regexp.MustCompile("Invalid import ID"),
+			},
+			// Update
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_member" "test" {
+  team_id   = "t-eng"
+  email     = "Ada@Example.com"
+  team_lead = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("span_team_member.test", "team_lead", "true"),
+					testAccCheckTeamMembers(srv, "t-eng", map[string]bool{"ada@example.com": true, "grace@example.com": false}),
+					testAccCheckCalls(srv, apitest.RouteRemoveMember, 0),
+				),
+			},
+		},
+	})
+}
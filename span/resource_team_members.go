@@ -0,0 +1,337 @@
+package span
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &TeamMembersResource{}
+	_ resource.ResourceWithConfigure   = &TeamMembersResource{}
+	_ resource.ResourceWithImportState = &TeamMembersResource{}
+	_ resource.ResourceWithModifyPlan  = &TeamMembersResource{}
+)
+
+func NewTeamMembersResource() resource.Resource {
+	return &TeamMembersResource{}
+}
+
+// TeamMembersResource authoritatively manages the members of a team, any
+// member missing from the configuration is removed from the team.
+type TeamMembersResource struct {
+	apiClient api.SpanAPIClient
+}
+
+type teamMembersResourceData struct {
+	TeamID  types.String `tfsdk:"team_id"`
+	Members types.Set    `tfsdk:"members"`
+}
+
+type teamMembership struct {
+	Email    types.String `tfsdk:"email"`
+	TeamLead types.Bool   `tfsdk:"team_lead"`
+}
+
+func (tm teamMembership) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"email":     types.StringType,
+		"team_lead": types.BoolType,
+	}
+}
+
+func (tm teamMembersResourceData) Attributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"team_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the team.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"members": schema.SetNestedAttribute{
+			MarkdownDescription: "Every member of the team. People missing from this set are removed from the team.",
+			Required:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"email": schema.StringAttribute{
+						MarkdownDescription: "Email of the person.",
+						Required:            true,
+					},
+					"team_lead": schema.BoolAttribute{
+						MarkdownDescription: "Whether the person leads the team. Defaults to `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+		},
+	}
+}
+
+// memberships decodes the members set keyed by lower cased email.
+func (tm teamMembersResourceData) memberships(ctx context.Context, diags *diag.Diagnostics) map[string]teamMembership {
+	var members []teamMembership
+	diags.Append(tm.Members.ElementsAs(ctx, &members, false)...)
+
+	out := make(map[string]teamMembership, len(members))
+	for _, m := range members {
+		out[strings.ToLower(m.Email.ValueString())] = m
+	}
+	return out
+}
+
+// apply maps the API members onto the resource model, keeping the email
+// casing of known members as emails are matched case insensitively.
+func (tm *teamMembersResourceData) apply(ctx context.Context, in []api.TeamMember, diags *diag.Diagnostics) {
+	known := tm.memberships(ctx, diags)
+
+	members := make([]teamMembership, 0, len(in))
+	for _, m := range in {
+		email := m.Email
+		if current, ok := known[strings.ToLower(email)]; ok {
+			email = current.Email.ValueString()
+		}
+
+		members = append(members, teamMembership{
+			Email:    types.StringValue(email),
+			TeamLead: types.BoolValue(m.TeamLead),
+		})
+	}
+
+	set, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: teamMembership{}.AttrTypes()}, members)
+	diags.Append(d...)
+
+	tm.Members = set
+}
+
+func (r *TeamMembersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_members"
+}
+
+func (r *TeamMembersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A managed resource for the complete membership of a team. " +
+			"Members not listed in the configuration are removed from the team. Import with the team id.",
+		Attributes: teamMembersResourceData{}.Attributes(),
+	}
+}
+
+func (r *TeamMembersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configuration Type",
+			fmt.Sprintf("Expected provider data but got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.apiClient = data.client
+}
+
+// ModifyPlan warns about the members which the plan removes from the team.
+// On updates these also show up in the diff of members, but on creation the
+// prior state is empty, so current members are fetched from Span.
+func (r *TeamMembersResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.apiClient == nil {
+		return
+	}
+
+	var planned, prior teamMembersResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planned)...)
+
+	if resp.Diagnostics.HasError() || planned.TeamID.IsUnknown() || planned.Members.IsUnknown() {
+		return
+	}
+
+	var current []string
+	if req.State.Raw.IsNull() {
+		team, err := r.apiClient.FindTeamByID(ctx, planned.TeamID.ValueString())
+		if err != nil {
+			// The team may not exist yet, Create reports any remaining issue.
+			tflog.Debug(ctx, "could not load team members for plan", map[string]any{"error": err.Error()})
+			return
+		}
+
+		for _, m := range team.Members {
+			current = append(current, m.Email)
+		}
+	} else {
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+		for _, m := range prior.memberships(ctx, &resp.Diagnostics) {
+			current = append(current, m.Email.ValueString())
+		}
+	}
+
+	desired := planned.memberships(ctx, &resp.Diagnostics)
+
+	var removed []string
+	for _, email := range current {
+		if _, ok := desired[strings.ToLower(email)]; !ok {
+			removed = append(removed, email)
+		}
+	}
+
+	if len(removed) == 0 {
+		return
+	}
+
+	sort.Strings(removed)
+	resp.Diagnostics.AddWarning(
+		"Team members will be removed",
+		fmt.Sprintf("The following members of team with ID %s are not in the configuration and will be removed: %s.",
+			planned.TeamID.ValueString(), strings.Join(removed, ", ")),
+	)
+}
+
+func (r *TeamMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data teamMembersResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data teamMembersResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.apiClient.FindTeamByID(ctx, data.TeamID.ValueString())
+	if api.IsNotFound(err) {
+		tflog.Warn(ctx, "team not found, removing members from state", map[string]any{"team_id": data.TeamID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err)
+		return
+	}
+
+	data.apply(ctx, team.Members, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data teamMembersResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the members known to the state from the team.
+func (r *TeamMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data teamMembersResourceData
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, m := range data.memberships(ctx, &resp.Diagnostics) {
+		err := r.apiClient.RemoveTeamMember(ctx, data.TeamID.ValueString(), m.Email.ValueString())
+		if err != nil && !api.IsNotFound(err) {
+			addAPIError(&resp.Diagnostics, err)
+			return
+		}
+	}
+}
+
+// sync makes the members of the team match data, adding and updating the
+// configured members before removing the others.
+func (r *TeamMembersResource) sync(ctx context.Context, data *teamMembersResourceData, diags *diag.Diagnostics) {
+	teamID := data.TeamID.ValueString()
+
+	team, err := r.apiClient.FindTeamByID(ctx, teamID)
+	if err != nil {
+		addAPIError(diags, err)
+		return
+	}
+
+	desired := data.memberships(ctx, diags)
+	if diags.HasError() {
+		return
+	}
+
+	for _, key := range sortedKeys(desired) {
+		m := desired[key]
+
+		current := findMember(team, m.Email.ValueString())
+		if current != nil && current.TeamLead == m.TeamLead.ValueBool() {
+			continue
+		}
+
+		_, err := r.apiClient.SetTeamMember(ctx, teamID, m.Email.ValueString(), api.SetTeamMemberRequest{TeamLead: m.TeamLead.ValueBool()})
+		if err != nil {
+			addAPIError(diags, err)
+			return
+		}
+	}
+
+	for _, m := range team.Members {
+		if _, ok := desired[strings.ToLower(m.Email)]; ok {
+			continue
+		}
+
+		tflog.Info(ctx, "removing team member missing from configuration", map[string]any{"team_id": teamID, "email": m.Email})
+
+		err := r.apiClient.RemoveTeamMember(ctx, teamID, m.Email)
+		if err != nil && !api.IsNotFound(err) {
+			addAPIError(diags, err)
+			return
+		}
+	}
+}
+
+func (r *TeamMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("team_id"), req, resp)
+}
@@ -0,0 +1,234 @@
+package span
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/api"
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccTeamMembersResource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTeamMembers(srv, "t-platform", nil),
+		Steps: []resource.TestStep{
+			// Members missing from the configuration are removed
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_members" "test" {
+  team_id = "t-platform"
+  members = [
+    { email = "Ada@Example.com", team_lead = true },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("span_team_members.test", "members.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("span_team_members.test", "members.*", map[string]string{
+						"email":     "Ada@Example.com",
+						"team_lead": "true",
+					}),
+					testAccCheckTeamMembers(srv, "t-platform", map[string]bool{"ada@example.com": true}),
+					testAccCheckCalls(srv, apitest.RouteRemoveMember, 1),
+					// Ada already leads the team.
+					testAccCheckCalls(srv, apitest.RouteSetMember, 0),
+				),
+			},
+			// The email casing returned by Span doesn't cause a diff
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_members" "test" {
+  team_id = "t-platform"
+  members = [
+    { email = "Ada@Example.com", team_lead = true },
+  ]
+}
+`,
+				PlanOnly: true,
+			},
+			// Import by team id
+			{
+				ResourceName:  "span_team_members.test",
+				ImportState:   true,
+				ImportStateId: "t-platform",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("got %d imported states, want 1", len(states))
+					}
+					attrs := states[0].Attributes
+					if attrs["team_id"] != "t-platform" || attrs["members.#"] != "1" || attrs["members.0.email"] != "ada@example.com" {
+						return fmt.Errorf("unexpected imported attributes: %v", attrs)
+					}
+					return nil
+				},
+			},
+			// Add and demote members
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_members" "test" {
+  team_id = "t-platform"
+  members = [
+    { email = "Ada@Example.com" },
+    { email = "grace@example.com", team_lead = true },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("span_team_members.test", "members.#", "2"),
+					testAccCheckTeamMembers(srv, "t-platform", map[string]bool{"ada@example.com": false, "grace@example.com": true}),
+				),
+			},
+			// Unknown people are rejected by Span
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team_members" "test" {
+  team_id = "t-platform"
+  members = [
+    { email = "Ada@Example.com" },
+    { email = "nobody@example.com" },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("Person nobody@example.com not found"),
+			},
+		},
+	})
+}
+
+func TestTeamMembersResourceModifyPlan(t *testing.T) {
+	srv := newTestServer(t)
+	client, err := api.NewSpanAPIClient(api.WithEndpoint(srv.Endpoint()), api.WithToken(testAccToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &TeamMembersResource{apiClient: client}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	sch := schemaResp.Schema
+
+	// value builds a plan or state holding the given members of t-platform,
+	// nil meaning that the resource doesn't exist.
+	value := func(emails []string) tftypes.Value {
+		if emails == nil {
+			return tftypes.NewValue(sch.Type().TerraformType(ctx), nil)
+		}
+
+		members := make([]attr.Value, 0, len(emails))
+		for _, email := range emails {
+			members = append(members, types.ObjectValueMust(teamMembership{}.AttrTypes(), map[string]attr.Value{
+				"email":     types.StringValue(email),
+				"team_lead": types.BoolValue(false),
+			}))
+		}
+
+		state := tfsdk.State{Schema: sch}
+		diags := state.Set(ctx, teamMembersResourceData{
+			TeamID:  types.StringValue("t-platform"),
+			Members: types.SetValueMust(types.ObjectType{AttrTypes: teamMembership{}.AttrTypes()}, members),
+		})
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		return state.Raw
+	}
+
+	tests := []struct {
+		name    string
+		prior   []string
+		planned []string
+		want    string
+	}{
+		{
+			name:    "create removing current members",
+			planned: []string{"ADA@example.com"},
+			want:    "The following members of team with ID t-platform are not in the configuration and will be removed: grace@example.com.",
+		},
+		{
+			name:    "create keeping current members",
+			planned: []string{"ada@example.com", "Grace@Example.com"},
+		},
+		{
+			name:    "update removing members",
+			prior:   []string{"ada@example.com", "grace@example.com", "hedy@example.com"},
+			planned: []string{"grace@example.com"},
+			want:    "The following members of team with ID t-platform are not in the configuration and will be removed: ada@example.com, hedy@example.com.",
+		},
+		{
+			name:    "update adding members",
+			prior:   []string{"ada@example.com"},
+			planned: []string{"ada@example.com", "grace@example.com"},
+		},
+		{
+			name:  "destroy",
+			prior: []string{"ada@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: sch, Raw: value(tt.planned)}
+			req := fwresource.ModifyPlanRequest{
+				Plan:  plan,
+				State: tfsdk.State{Schema: sch, Raw: value(tt.prior)},
+			}
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+
+			r.ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			var got []string
+			for _, d := range resp.Diagnostics.Warnings() {
+				got = append(got, d.Detail())
+			}
+
+			var want []string
+			if tt.want != "" {
+				want = []string{tt.want}
+			}
+			if strings.Join(got, "\n") != strings.Join(want, "\n") {
+				t.Errorf("got warnings %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// testAccCheckTeamMembers checks the members of a team within the fake
+// server, keyed by lower cased email with whether they lead the team.
+func testAccCheckTeamMembers(srv *apitest.Server, teamID string, want map[string]bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		team, ok := srv.Team(teamID)
+		if !ok {
+			return fmt.Errorf("team %s not found", teamID)
+		}
+
+		got := map[string]bool{}
+		for _, m := range team.Members {
+			got[strings.ToLower(m.Email)] = m.TeamLead
+		}
+
+		if !maps.Equal(got, want) {
+			return fmt.Errorf("team %s: got members %v, want %v", teamID, got, want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+package span
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/attuned-corp/terraform-provider-span/internal/apitest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccTeamResource(t *testing.T) {
+	srv := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTeamDestroyed(srv),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team" "test" {
+  name      = "Security"
+  parent_id = "t-eng"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("span_team.test", "id"),
+					resource.TestCheckResourceAttrSet("span_team.test", "created_at"),
+					resource.TestCheckResourceAttr("span_team.test", "slug", "security"),
+					resource.TestCheckResourceAttr("span_team.test", "parent_id", "t-eng"),
+					testAccCheckTeam(srv, "span_team.test", "Security", "t-eng"),
+				),
+			},
+			// Import by id
+			{
+				ResourceName:      "span_team.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import by slug
+			{
+				ResourceName:      "span_team.test",
+				ImportState:       true,
+				ImportStateId:     "security",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "span_team.test",
+				ImportState:   true,
+				ImportStateId: "missing",
+				ExpectError:   regexp.MustCompile("Cannot import team"),
+			},
+			// Rename and move to the top level in place
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team" "test" {
+  name = "Security Engineering"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("span_team.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("span_team.test", "name", "Security Engineering"),
+					resource.TestCheckResourceAttr("span_team.test", "slug", "security"),
+					resource.TestCheckNoResourceAttr("span_team.test", "parent_id"),
+					testAccCheckTeam(srv, "span_team.test", "Security Engineering", ""),
+				),
+			},
+			// Configuring the current slug changes nothing
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team" "test" {
+  name = "Security Engineering"
+  slug = "security"
+}
+`,
+				PlanOnly: true,
+			},
+			// A new slug replaces the team
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "span_team" "test" {
+  name = "Security Engineering"
+  slug = "appsec"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("span_team.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("span_team.test", "slug", "appsec"),
+					testAccCheckTeam(srv, "span_team.test", "Security Engineering", ""),
+					testAccCheckCalls(srv, apitest.RouteDeleteTeam, 1),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckTeam checks the team of the resource at address within the
+// fake server.
+func testAccCheckTeam(srv *apitest.Server, address, name, parentID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("%s not found in state", address)
+		}
+
+		team, ok := srv.Team(rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("team %s not found", rs.Primary.ID)
+		}
+		if team.Name != name || team.ParentID != parentID {
+			return fmt.Errorf("team %s: got name %q and parent %q, want %q and %q", team.ID, team.Name, team.ParentID, name, parentID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTeamDestroyed(srv *apitest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "span_team" {
+				continue
+			}
+			if _, ok := srv.Team(rs.Primary.ID); ok {
+				return fmt.Errorf("team %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
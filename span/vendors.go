@@ -0,0 +1,268 @@
+package span
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	dynamic "github.com/attuned-corp/terraform-provider-span/span/internal/serde"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// vendorSchema describes the settings Span understands for a well known
+// manifest vendor, keyed by setting name with their description.
+type vendorSchema struct {
+	Description string
+	Settings    map[string]string
+}
+
+// vendorSchemas is the registry of well known manifest vendors, keyed by the
+// vendor name used within the manifest.
+var vendorSchemas = map[string]vendorSchema{
+	"pagerduty": {
+		Description: "PagerDuty on-call settings.",
+		Settings: map[string]string{
+			"schedule":          "Id of the PagerDuty schedule, e.g. `PI7DH85`.",
+			"escalation_policy": "Id of the PagerDuty escalation policy.",
+			"service":           "Id of the PagerDuty service.",
+		},
+	},
+	"datadog": {
+		Description: "Datadog team settings.",
+		Settings: map[string]string{
+			"slug":        "Slug of the Datadog team.",
+			"team_handle": "Handle of the Datadog team, e.g. `@span-core`.",
+		},
+	},
+	"opsgenie": {
+		Description: "Opsgenie on-call settings.",
+		Settings: map[string]string{
+			"team":     "Name of the Opsgenie team.",
+			"schedule": "Name of the Opsgenie schedule.",
+		},
+	},
+	"slack": {
+		Description: "Slack settings.",
+		Settings: map[string]string{
+			"channel": "Slack channel of the team, e.g. `#core-team`.",
+		},
+	},
+	"github": {
+		Description: "GitHub settings.",
+		Settings: map[string]string{
+			"team_slug": "Slug of the GitHub team.",
+		},
+	},
+	"jira": {
+		Description: "Jira settings.",
+		Settings: map[string]string{
+			"project_key": "Key of the Jira project, e.g. `CORE`.",
+		},
+	},
+}
+
+// customVendors is the typed_vendors attribute holding the settings of any
+// vendor missing from vendorSchemas.
+const customVendors = "custom"
+
+func typedVendorsAttribute() schema.SingleNestedAttribute {
+	attributes := map[string]schema.Attribute{
+		customVendors: schema.DynamicAttribute{
+			MarkdownDescription: "Settings of vendors without typed attributes, keyed by vendor name, e.g. `{ statuspage = { page = \"kctbh9vrtdwd\" } }`.",
+			Optional:            true,
+		},
+	}
+
+	for name, vendor := range vendorSchemas {
+		settings := make(map[string]schema.Attribute, len(vendor.Settings))
+		for setting, description := range vendor.Settings {
+			settings[setting] = schema.StringAttribute{
+				MarkdownDescription: description,
+				Optional:            true,
+			}
+		}
+
+		attributes[name] = schema.SingleNestedAttribute{
+			MarkdownDescription: vendor.Description,
+			Optional:            true,
+			Attributes:          settings,
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Vendor properties of the manifest with typed settings for well known vendors, validated at plan time. Conflicts with `vendors` and `vendors_input`.",
+		Optional:            true,
+		Attributes:          attributes,
+	}
+}
+
+func typedVendorsAttrTypes() map[string]attr.Type {
+	attrTypes := map[string]attr.Type{
+		customVendors: types.DynamicType,
+	}
+
+	for name := range vendorSchemas {
+		attrTypes[name] = vendorAttrType(name)
+	}
+
+	return attrTypes
+}
+
+func vendorAttrType(name string) types.ObjectType {
+	settings := make(map[string]attr.Type, len(vendorSchemas[name].Settings))
+	for setting := range vendorSchemas[name].Settings {
+		settings[setting] = types.StringType
+	}
+	return types.ObjectType{AttrTypes: settings}
+}
+
+// vendorsFromTyped flattens typed_vendors into the manifest vendors object.
+func vendorsFromTyped(typed types.Object) (map[string]any, error) {
+	vendors := map[string]any{}
+
+	for name, v := range typed.Attributes() {
+		if v.IsNull() {
+			continue
+		}
+
+		if !dynamic.IsFullyKnown(v) {
+			return nil, fmt.Errorf("%s is not known yet", name)
+		}
+
+		if name == customVendors {
+			b, err := dynamic.ToJSON(v.(types.Dynamic))
+			if err != nil {
+				return nil, err
+			}
+
+			custom, err := decodeVendors(b)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be an object: %w", customVendors, err)
+			}
+
+			for k, settings := range custom {
+				vendors[k] = settings
+			}
+			continue
+		}
+
+		settings := map[string]any{}
+		for setting, sv := range v.(types.Object).Attributes() {
+			if !sv.IsNull() {
+				settings[setting] = sv.(types.String).ValueString()
+			}
+		}
+		vendors[name] = settings
+	}
+
+	return vendors, nil
+}
+
+// typedFromVendors maps the manifest vendors object onto typed_vendors.
+// Vendors whose settings don't fit their typed attributes are kept under
+// custom so that nothing returned by Span gets lost.
+func typedFromVendors(vendors map[string]any) (types.Object, error) {
+	attrs := map[string]attr.Value{
+		customVendors: types.DynamicNull(),
+	}
+	custom := map[string]any{}
+
+	for name := range vendorSchemas {
+		attrs[name] = types.ObjectNull(vendorAttrType(name).AttrTypes)
+	}
+
+	for name, settings := range vendors {
+		if _, ok := vendorSchemas[name]; !ok || len(vendorSettingsErrors(name, settings)) > 0 {
+			custom[name] = settings
+			continue
+		}
+
+		values := map[string]attr.Value{}
+		for setting := range vendorSchemas[name].Settings {
+			values[setting] = types.StringNull()
+		}
+		for setting, sv := range settings.(map[string]any) {
+			values[setting] = types.StringValue(sv.(string))
+		}
+
+		obj, diags := types.ObjectValue(vendorAttrType(name).AttrTypes, values)
+		if diags.HasError() {
+			return types.ObjectNull(typedVendorsAttrTypes()), fmt.Errorf("Unexpected error mapping %s settings", name)
+		}
+		attrs[name] = obj
+	}
+
+	if len(custom) > 0 {
+		b, err := json.Marshal(custom)
+		if err != nil {
+			return types.ObjectNull(typedVendorsAttrTypes()), err
+		}
+
+		if attrs[customVendors], err = dynamic.FromJSONWithMode(b, dynamic.ModeCollections); err != nil {
+			return types.ObjectNull(typedVendorsAttrTypes()), err
+		}
+	}
+
+	obj, diags := types.ObjectValue(typedVendorsAttrTypes(), attrs)
+	if diags.HasError() {
+		return types.ObjectNull(typedVendorsAttrTypes()), fmt.Errorf("Unexpected error mapping typed vendors")
+	}
+
+	return obj, nil
+}
+
+// vendorsErrors checks the settings of every well known vendor within
+// vendors against the registry. Other vendors are not checked.
+func vendorsErrors(vendors map[string]any) []string {
+	var errs []string
+
+	for _, name := range sortedKeys(vendors) {
+		errs = append(errs, vendorSettingsErrors(name, vendors[name])...)
+	}
+
+	return errs
+}
+
+func vendorSettingsErrors(name string, settings any) []string {
+	vendor, ok := vendorSchemas[name]
+	if !ok {
+		return nil
+	}
+
+	values, ok := settings.(map[string]any)
+	if !ok {
+		return []string{fmt.Sprintf("`%s` must be an object.", name)}
+	}
+
+	supported := make([]string, 0, len(vendor.Settings))
+	for setting := range vendor.Settings {
+		supported = append(supported, setting)
+	}
+	sort.Strings(supported)
+
+	var errs []string
+	for _, setting := range sortedKeys(values) {
+		if _, ok := vendor.Settings[setting]; !ok {
+			errs = append(errs, fmt.Sprintf("`%s.%s` is not a supported setting, expected one of: %s.", name, setting, strings.Join(supported, ", ")))
+			continue
+		}
+
+		if _, ok := values[setting].(string); !ok {
+			errs = append(errs, fmt.Sprintf("`%s.%s` must be a string.", name, setting))
+		}
+	}
+
+	return errs
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
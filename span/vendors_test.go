@@ -0,0 +1,154 @@
+package span
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVendorSettingsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		vendor   string
+		settings any
+		want     []string
+	}{
+		{
+			name:     "valid",
+			vendor:   "pagerduty",
+			settings: map[string]any{"schedule": "PI7DH85", "service": "PSVC01"},
+		},
+		{
+			name:     "unknown vendor",
+			vendor:   "statuspage",
+			settings: map[string]any{"page": "kctbh9vrtdwd"},
+		},
+		{
+			name:     "unknown vendor with any value",
+			vendor:   "statuspage",
+			settings: map[string]any{"page": json.Number("42"), "components": []any{"api", map[string]any{"id": true}}},
+		},
+		{
+			name:     "unknown setting",
+			vendor:   "slack",
+			settings: map[string]any{"channel": "#core-team", "workspace": "span"},
+			want:     []string{"`slack.workspace` is not a supported setting, expected one of: channel."},
+		},
+		{
+			name:     "wrong setting type",
+			vendor:   "pagerduty",
+			settings: map[string]any{"schedule": json.Number("42"), "service": map[string]any{"id": "PSVC01"}},
+			want: []string{
+				"`pagerduty.schedule` must be a string.",
+				"`pagerduty.service` must be a string.",
+			},
+		},
+		{
+			name:     "not an object",
+			vendor:   "jira",
+			settings: "CORE",
+			want:     []string{"`jira` must be an object."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vendorSettingsErrors(tt.vendor, tt.settings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedFromVendors(t *testing.T) {
+	tests := []struct {
+		name       string
+		vendors    string
+		wantTyped  []string
+		wantCustom []string
+	}{
+		{
+			name:      "well known vendors",
+			vendors:   `{"pagerduty": {"schedule": "PI7DH85"}, "slack": {"channel": "#core-team"}}`,
+			wantTyped: []string{"pagerduty", "slack"},
+		},
+		{
+			name:       "unknown vendor",
+			vendors:    `{"statuspage": {"page": "kctbh9vrtdwd", "components": [1, 2]}}`,
+			wantCustom: []string{"statuspage"},
+		},
+		{
+			name:       "unknown setting",
+			vendors:    `{"slack": {"channel": "#core-team", "workspace": "span"}, "jira": {"project_key": "CORE"}}`,
+			wantTyped:  []string{"jira"},
+			wantCustom: []string{"slack"},
+		},
+		{
+			name:       "wrong setting type",
+			vendors:    `{"pagerduty": {"schedule": 42}}`,
+			wantCustom: []string{"pagerduty"},
+		},
+		{
+			name:    "empty",
+			vendors: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vendors, err := decodeVendors([]byte(tt.vendors))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			typed, err := typedFromVendors(vendors)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotTyped []string
+			for _, name := range sortedKeys(typed.Attributes()) {
+				if name != customVendors && !typed.Attributes()[name].IsNull() {
+					gotTyped = append(gotTyped, name)
+				}
+			}
+			if !reflect.DeepEqual(gotTyped, tt.wantTyped) {
+				t.Errorf("got typed vendors %q, want %q", gotTyped, tt.wantTyped)
+			}
+
+			custom := typed.Attributes()[customVendors].(types.Dynamic)
+			if custom.IsNull() != (len(tt.wantCustom) == 0) {
+				t.Fatalf("got custom %s, want vendors %q", custom, tt.wantCustom)
+			}
+			if !custom.IsNull() {
+				var gotCustom []string
+				for _, name := range sortedKeys(custom.UnderlyingValue().(types.Map).Elements()) {
+					gotCustom = append(gotCustom, name)
+				}
+				if !reflect.DeepEqual(gotCustom, tt.wantCustom) {
+					t.Errorf("got custom vendors %q, want %q", gotCustom, tt.wantCustom)
+				}
+			}
+
+			// Mapping back yields the vendors as returned by Span.
+			roundTrip, err := vendorsFromTyped(typed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := json.Marshal(roundTrip)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := json.Marshal(vendors)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("round trip: got %s, want %s", got, want)
+			}
+		})
+	}
+}